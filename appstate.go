@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -361,6 +362,19 @@ func (cli *Client) dispatchAppState(ctx context.Context, name appstate.WAPatchNa
 			Action:       mutation.Action.GetUnarchiveChatsSetting(),
 			FromFullSync: fullSync,
 		}
+	case appstate.IndexLock:
+		eventToDispatch = &events.ChatLock{
+			JID:          jid,
+			Timestamp:    ts,
+			Action:       mutation.Action.GetLockChatAction(),
+			FromFullSync: fullSync,
+		}
+	case appstate.IndexSettingChatLock:
+		eventToDispatch = &events.ChatLockSettings{
+			Timestamp:    ts,
+			Action:       mutation.Action.GetChatLockSettings(),
+			FromFullSync: fullSync,
+		}
 	case appstate.IndexUserStatusMute:
 		eventToDispatch = &events.UserStatusMute{
 			JID:          jid,
@@ -376,6 +390,83 @@ func (cli *Client) dispatchAppState(ctx context.Context, name appstate.WAPatchNa
 			Action:       act,
 			FromFullSync: fullSync,
 		}
+	case appstate.IndexLabelReordering:
+		eventToDispatch = &events.LabelReordering{
+			Timestamp:    ts,
+			Action:       mutation.Action.GetLabelReorderingAction(),
+			FromFullSync: fullSync,
+		}
+	case appstate.IndexQuickReply:
+		if len(mutation.Index) < 2 {
+			return
+		}
+		act := mutation.Action.GetQuickReplyAction()
+		eventToDispatch = &events.QuickReply{
+			ID:           mutation.Index[1],
+			Timestamp:    ts,
+			Action:       act,
+			FromFullSync: fullSync,
+		}
+		if cli.Store.QuickReplies != nil {
+			if act.GetDeleted() {
+				storeUpdateError = cli.Store.QuickReplies.DeleteQuickReply(ctx, mutation.Index[1])
+			} else {
+				storeUpdateError = cli.Store.QuickReplies.PutQuickReply(ctx, types.QuickReply{
+					ID:       mutation.Index[1],
+					Shortcut: act.GetShortcut(),
+					Message:  act.GetMessage(),
+					Keywords: act.GetKeywords(),
+					Count:    act.GetCount(),
+				})
+			}
+		}
+	case appstate.IndexDeviceAgent:
+		if len(mutation.Index) < 2 {
+			return
+		}
+		eventToDispatch = &events.DeviceAgent{
+			AgentID:      mutation.Index[1],
+			Timestamp:    ts,
+			Action:       mutation.Action.GetAgentAction(),
+			FromFullSync: fullSync,
+		}
+	case appstate.IndexAgentChatAssignment:
+		eventToDispatch = &events.ChatAssignment{
+			JID:          jid,
+			Timestamp:    ts,
+			Action:       mutation.Action.GetChatAssignment(),
+			FromFullSync: fullSync,
+		}
+	case appstate.IndexAgentChatAssignmentOpenedStatus:
+		if len(mutation.Index) < 3 {
+			return
+		}
+		eventToDispatch = &events.ChatAssignmentOpenedStatus{
+			JID:          jid,
+			AgentID:      mutation.Index[2],
+			Timestamp:    ts,
+			Action:       mutation.Action.GetChatAssignmentOpenedStatus(),
+			FromFullSync: fullSync,
+		}
+	case appstate.IndexBusinessBroadcastList:
+		if len(mutation.Index) < 2 {
+			return
+		}
+		// The full recipient list is always included in business_broadcast_list mutations.
+		// The broadcast_jid (BusinessBroadcastAssociationAction) and broadcast indexes don't carry any membership info
+		// and have no field in SyncActionValue, so they're intentionally not handled here.
+		if jid.Server != types.BroadcastServer && !strings.ContainsRune(mutation.Index[1], '@') {
+			jid = types.NewJID(mutation.Index[1], types.BroadcastServer)
+		}
+		act := mutation.Action.GetBusinessBroadcastListAction()
+		eventToDispatch = &events.BroadcastList{JID: jid, Timestamp: ts, Action: act, FromFullSync: fullSync}
+		if cli.Store.BroadcastLists != nil {
+			if act.GetDeleted() {
+				storeUpdateError = cli.Store.BroadcastLists.DeleteBroadcastList(ctx, jid)
+			} else {
+				storeUpdateError = cli.Store.BroadcastLists.PutBroadcastList(ctx, broadcastListFromAction(jid, act))
+			}
+		}
 	case appstate.IndexLabelAssociationChat:
 		if len(mutation.Index) < 3 {
 			return
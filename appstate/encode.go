@@ -9,9 +9,11 @@ import (
 
 	"google.golang.org/protobuf/proto"
 
+	"go.mau.fi/whatsmeow/proto/waChatLockSettings"
 	"go.mau.fi/whatsmeow/proto/waCommon"
 	"go.mau.fi/whatsmeow/proto/waServerSync"
 	"go.mau.fi/whatsmeow/proto/waSyncAction"
+	"go.mau.fi/whatsmeow/proto/waUserPassword"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/util/cbcutil"
 )
@@ -208,6 +210,167 @@ func BuildLabelEdit(labelID string, labelName string, labelColor int32, deleted
 	}
 }
 
+// BuildLabelReordering builds an app state patch for changing the order of labels.
+// The list should contain all label IDs in the new order.
+func BuildLabelReordering(sortedLabelIDs []int32) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{{
+			Index:   []string{IndexLabelReordering},
+			Version: 1,
+			Value: &waSyncAction.SyncActionValue{
+				LabelReorderingAction: &waSyncAction.LabelReorderingAction{
+					SortedLabelIDs: sortedLabelIDs,
+				},
+			},
+		}},
+	}
+}
+
+func newQuickReplyMutation(id string, action *waSyncAction.QuickReplyAction) MutationInfo {
+	return MutationInfo{
+		Index:   []string{IndexQuickReply, id},
+		Version: 2,
+		Value: &waSyncAction.SyncActionValue{
+			QuickReplyAction: action,
+		},
+	}
+}
+
+// BuildQuickReply builds an app state patch for creating or editing a business quick reply.
+//
+// When creating a new quick reply, the ID should be a new unique ID, e.g. the current unix timestamp in milliseconds.
+func BuildQuickReply(reply types.QuickReply) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{
+			newQuickReplyMutation(reply.ID, &waSyncAction.QuickReplyAction{
+				Shortcut: proto.String(reply.Shortcut),
+				Message:  proto.String(reply.Message),
+				Keywords: reply.Keywords,
+				Count:    proto.Int32(reply.Count),
+				Deleted:  proto.Bool(false),
+			}),
+		},
+	}
+}
+
+// BuildDeleteQuickReply builds an app state patch for deleting a business quick reply.
+func BuildDeleteQuickReply(id string) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{
+			newQuickReplyMutation(id, &waSyncAction.QuickReplyAction{
+				Deleted: proto.Bool(true),
+			}),
+		},
+	}
+}
+
+// BuildDeviceAgent builds an app state patch for creating, renaming or deleting an agent in a multi-agent business account.
+//
+// The device ID is the ID of the linked device that the agent uses.
+func BuildDeviceAgent(agentID, name string, deviceID int32, deleted bool) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{{
+			Index:   []string{IndexDeviceAgent, agentID},
+			Version: 7,
+			Value: &waSyncAction.SyncActionValue{
+				AgentAction: &waSyncAction.AgentAction{
+					Name:      proto.String(name),
+					DeviceID:  proto.Int32(deviceID),
+					IsDeleted: proto.Bool(deleted),
+				},
+			},
+		}},
+	}
+}
+
+// BuildChatAssignment builds an app state patch for assigning a chat to an agent in a multi-agent business account.
+//
+// An empty agent ID removes the assignment.
+func BuildChatAssignment(target types.JID, agentID string) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{{
+			Index:   []string{IndexAgentChatAssignment, target.String()},
+			Version: 7,
+			Value: &waSyncAction.SyncActionValue{
+				ChatAssignment: &waSyncAction.ChatAssignmentAction{
+					DeviceAgentID: proto.String(agentID),
+				},
+			},
+		}},
+	}
+}
+
+// BuildChatAssignmentOpenedStatus builds an app state patch for marking an assigned chat as opened (or not opened)
+// by the given agent.
+func BuildChatAssignmentOpenedStatus(target types.JID, agentID string, opened bool) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{{
+			Index:   []string{IndexAgentChatAssignmentOpenedStatus, target.String(), agentID},
+			Version: 7,
+			Value: &waSyncAction.SyncActionValue{
+				ChatAssignmentOpenedStatus: &waSyncAction.ChatAssignmentOpenedStatusAction{
+					ChatOpened: proto.Bool(opened),
+				},
+			},
+		}},
+	}
+}
+
+func newBroadcastListMutation(target types.JID, action *waSyncAction.BusinessBroadcastListAction) MutationInfo {
+	return MutationInfo{
+		Index:   []string{IndexBusinessBroadcastList, target.String()},
+		Version: 1,
+		Value: &waSyncAction.SyncActionValue{
+			BusinessBroadcastListAction: action,
+		},
+	}
+}
+
+// BuildBroadcastList builds an app state patch for creating or editing a broadcast list.
+//
+// Recipients should always have the LID set, the phone number is optional. When creating a new list,
+// the JID should be a new unique ID in the broadcast server, e.g. the current unix timestamp followed by @broadcast.
+func BuildBroadcastList(list types.BroadcastList) PatchInfo {
+	participants := make([]*waSyncAction.BroadcastListParticipant, len(list.Recipients))
+	for i, recipient := range list.Recipients {
+		participants[i] = &waSyncAction.BroadcastListParticipant{
+			LidJID: proto.String(recipient.LID.String()),
+		}
+		if !recipient.PN.IsEmpty() {
+			participants[i].PnJID = proto.String(recipient.PN.String())
+		}
+	}
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{
+			newBroadcastListMutation(list.JID, &waSyncAction.BusinessBroadcastListAction{
+				Deleted:      proto.Bool(false),
+				Participants: participants,
+				ListName:     proto.String(list.Name),
+				LabelIDs:     list.LabelIDs,
+			}),
+		},
+	}
+}
+
+// BuildDeleteBroadcastList builds an app state patch for deleting a broadcast list.
+func BuildDeleteBroadcastList(target types.JID) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegular,
+		Mutations: []MutationInfo{
+			newBroadcastListMutation(target, &waSyncAction.BusinessBroadcastListAction{
+				Deleted: proto.Bool(true),
+			}),
+		},
+	}
+}
+
 func newSettingPushNameMutation(pushName string) MutationInfo {
 	return MutationInfo{
 		Index:   []string{IndexSettingPushName},
@@ -230,6 +393,41 @@ func BuildSettingPushName(pushName string) PatchInfo {
 	}
 }
 
+// BuildLockChat builds an app state patch for locking or unlocking a chat.
+func BuildLockChat(target types.JID, locked bool) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegularLow,
+		Mutations: []MutationInfo{{
+			Index:   []string{IndexLock, target.String()},
+			Version: 7,
+			Value: &waSyncAction.SyncActionValue{
+				LockChatAction: &waSyncAction.LockChatAction{
+					Locked: &locked,
+				},
+			},
+		}},
+	}
+}
+
+// BuildChatLockSettings builds an app state patch for changing the chat lock settings.
+//
+// The secret code is optional and can be created with whatsmeow.NewChatLockSecretCode.
+func BuildChatLockSettings(hideLockedChats bool, secretCode *waUserPassword.UserPassword) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegularLow,
+		Mutations: []MutationInfo{{
+			Index:   []string{IndexSettingChatLock},
+			Version: 7,
+			Value: &waSyncAction.SyncActionValue{
+				ChatLockSettings: &waChatLockSettings.ChatLockSettings{
+					HideLockedChats: &hideLockedChats,
+					SecretCode:      secretCode,
+				},
+			},
+		}},
+	}
+}
+
 func newStarMutation(targetJID, senderJID string, messageID types.MessageID, fromMe string, starred bool) MutationInfo {
 	return MutationInfo{
 		Index:   []string{IndexStar, targetJID, messageID, fromMe, senderJID},
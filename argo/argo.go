@@ -12,6 +12,7 @@ import (
 var (
 	Store                map[string]wire.Type
 	QueryIDToMessageName map[string]string
+	MessageNameToQueryID map[string]string
 
 	//go:embed argo-wire-type-store.argo
 	wireTypeStoreBytes []byte
@@ -44,6 +45,7 @@ func Init() error {
 			m[id] = name
 		}
 		QueryIDToMessageName = m
+		MessageNameToQueryID = src
 	})
 	return initErr
 }
@@ -60,3 +62,10 @@ func GetQueryIDToMessageName() (map[string]string, error) {
 	}
 	return QueryIDToMessageName, nil
 }
+
+func GetMessageNameToQueryID() (map[string]string, error) {
+	if err := Init(); err != nil {
+		return nil, err
+	}
+	return MessageNameToQueryID, nil
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// BotResponseTimeout is how long an unfinished bot response is remembered after its latest chunk.
+const BotResponseTimeout = 5 * time.Minute
+
+// BotResponse is the assembled state of a bot response that may be streamed in multiple chunks.
+type BotResponse struct {
+	Bot        types.JID
+	Chat       types.JID
+	PromptID   types.MessageID
+	ResponseID types.MessageID
+
+	// The full text of the response. Each chunk replaces the content of the previous ones like a normal edit,
+	// so this is always the latest text rather than a concatenation of the chunks.
+	Text    string
+	Message *waE2E.Message
+	// The number of chunks received so far.
+	Chunks    int
+	Completed bool
+	UpdatedAt time.Time
+}
+
+type botResponseState struct {
+	response BotResponse
+	waiters  []*BotConversation
+}
+
+type botResponseTracker struct {
+	responses map[types.MessageID]*botResponseState
+	lock      sync.Mutex
+}
+
+func newBotResponseTracker() *botResponseTracker {
+	return &botResponseTracker{
+		responses: make(map[types.MessageID]*botResponseState),
+	}
+}
+
+// BotConversation is a prompt sent to a bot using [Client.SendBotPrompt].
+// The response is assembled in the background as chunks arrive.
+type BotConversation struct {
+	Bot          types.JID
+	Chat         types.JID
+	PromptID     types.MessageID
+	SendResponse SendResponse
+
+	tracker  *botResponseTracker
+	lock     sync.RWMutex
+	response BotResponse
+	done     chan struct{}
+}
+
+// Response returns the current state of the response.
+func (bc *BotConversation) Response() BotResponse {
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+	return bc.response
+}
+
+// Done returns a channel that is closed when the last chunk of the response has been received.
+func (bc *BotConversation) Done() <-chan struct{} {
+	return bc.done
+}
+
+// Wait waits until the bot has finished responding and returns the final text.
+func (bc *BotConversation) Wait(ctx context.Context) (string, error) {
+	select {
+	case <-bc.done:
+		return bc.Response().Text, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Close stops tracking the response. This only needs to be called if the response is no longer needed
+// before it's completed, as finished conversations are cleaned up automatically.
+func (bc *BotConversation) Close() {
+	bc.tracker.removeWaiter(bc)
+}
+
+func (bc *BotConversation) update(resp BotResponse) {
+	bc.lock.Lock()
+	bc.response = resp
+	bc.lock.Unlock()
+	if resp.Completed {
+		close(bc.done)
+	}
+}
+
+func (bt *botResponseTracker) addWaiter(bc *BotConversation) {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+	state, ok := bt.responses[bc.PromptID]
+	if !ok {
+		state = &botResponseState{response: BotResponse{
+			Bot:       bc.Bot,
+			Chat:      bc.Chat,
+			PromptID:  bc.PromptID,
+			UpdatedAt: time.Now(),
+		}}
+		bt.responses[bc.PromptID] = state
+	}
+	state.waiters = append(state.waiters, bc)
+}
+
+func (bt *botResponseTracker) removeWaiter(bc *BotConversation) {
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+	state, ok := bt.responses[bc.PromptID]
+	if !ok {
+		return
+	}
+	for i, waiter := range state.waiters {
+		if waiter == bc {
+			state.waiters = append(state.waiters[:i], state.waiters[i+1:]...)
+			break
+		}
+	}
+	if len(state.waiters) == 0 && state.response.Chunks == 0 {
+		delete(bt.responses, bc.PromptID)
+	}
+}
+
+func (bt *botResponseTracker) pruneStale(now time.Time) {
+	cutoff := now.Add(-BotResponseTimeout)
+	for id, state := range bt.responses {
+		if len(state.waiters) == 0 && state.response.UpdatedAt.Before(cutoff) {
+			delete(bt.responses, id)
+		}
+	}
+}
+
+func (bt *botResponseTracker) handleChunk(info *types.MessageInfo, text string, msg *waE2E.Message) (BotResponse, []*BotConversation) {
+	promptID := info.MsgMetaInfo.TargetID
+	if promptID == "" {
+		promptID = info.ID
+	}
+	responseID := info.ID
+	if info.MsgBotInfo.EditType == types.EditTypeInner || info.MsgBotInfo.EditType == types.EditTypeLast {
+		responseID = info.MsgBotInfo.EditTargetID
+	}
+	now := time.Now()
+
+	bt.lock.Lock()
+	defer bt.lock.Unlock()
+	bt.pruneStale(now)
+	state, ok := bt.responses[promptID]
+	if !ok {
+		state = &botResponseState{response: BotResponse{
+			Bot:      info.Sender.ToNonAD(),
+			Chat:     info.Chat,
+			PromptID: promptID,
+		}}
+		bt.responses[promptID] = state
+	}
+	resp := &state.response
+	resp.ResponseID = responseID
+	resp.Text = text
+	resp.Message = msg
+	resp.Chunks++
+	resp.UpdatedAt = now
+	// Responses without an edit type aren't streamed, so the first message is also the last one
+	resp.Completed = info.MsgBotInfo.EditType == types.EditTypeLast || info.MsgBotInfo.EditType == ""
+	waiters := slices.Clone(state.waiters)
+	if resp.Completed {
+		delete(bt.responses, promptID)
+	}
+	return *resp, waiters
+}
+
+func getBotResponseContent(msg *waE2E.Message) (string, *waE2E.Message) {
+	if protoMsg := msg.GetProtocolMessage(); protoMsg.GetType() == waE2E.ProtocolMessage_MESSAGE_EDIT && protoMsg.GetEditedMessage() != nil {
+		msg = protoMsg.GetEditedMessage()
+	}
+	if msg.GetConversation() != "" {
+		return msg.GetConversation(), msg
+	}
+	return msg.GetExtendedTextMessage().GetText(), msg
+}
+
+func (cli *Client) handleBotResponseChunk(info *types.MessageInfo, msg *waE2E.Message) {
+	text, content := getBotResponseContent(msg)
+	resp, waiters := cli.botResponseTracker.handleChunk(info, text, content)
+	for _, waiter := range waiters {
+		waiter.update(resp)
+	}
+	cli.dispatchEvent(&events.BotResponseUpdated{
+		Info:       *info,
+		PromptID:   resp.PromptID,
+		ResponseID: resp.ResponseID,
+		EditType:   info.MsgBotInfo.EditType,
+		Text:       resp.Text,
+		Message:    resp.Message,
+	})
+	if resp.Completed {
+		cli.dispatchEvent(&events.BotResponseCompleted{
+			Info:       *info,
+			PromptID:   resp.PromptID,
+			ResponseID: resp.ResponseID,
+			Text:       resp.Text,
+			Message:    resp.Message,
+		})
+	}
+}
+
+// SendBotPrompt sends a text prompt to a bot and returns a BotConversation that assembles the streamed response.
+//
+// The to parameter is normally the bot JID (e.g. from [Client.GetBotListV2]). To invoke a bot inside another chat,
+// set to to the chat and pass the bot JID in the InlineBotJID field of the extra parameter.
+//
+// The individual response chunks are still emitted as normal events.Message, and the assembled response is
+// emitted as events.BotResponseUpdated and events.BotResponseCompleted. To wait for the full response, use
+//
+//	conv, err := cli.SendBotPrompt(ctx, types.NewMetaAIJID, "Hello")
+//	if err != nil {
+//		return err
+//	}
+//	defer conv.Close()
+//	text, err := conv.Wait(ctx)
+func (cli *Client) SendBotPrompt(ctx context.Context, to types.JID, prompt string, extra ...SendRequestExtra) (*BotConversation, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	var req SendRequestExtra
+	if len(extra) > 1 {
+		return nil, errors.New("only one extra parameter may be provided to SendBotPrompt")
+	} else if len(extra) == 1 {
+		req = extra[0]
+	}
+	bot := to
+	if !req.InlineBotJID.IsEmpty() {
+		bot = req.InlineBotJID
+	} else if !to.IsBot() {
+		return nil, ErrInvalidInlineBotID
+	}
+	if req.ID == "" {
+		req.ID = cli.GenerateMessageID()
+	}
+	conv := &BotConversation{
+		Bot:      bot,
+		Chat:     to,
+		PromptID: req.ID,
+		tracker:  cli.botResponseTracker,
+		done:     make(chan struct{}),
+	}
+	// Register the waiter before sending, as the first chunk may arrive before SendMessage returns
+	cli.botResponseTracker.addWaiter(conv)
+	resp, err := cli.SendMessage(ctx, to, &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String(prompt),
+		},
+	}, req)
+	if err != nil {
+		conv.Close()
+		return nil, err
+	}
+	conv.SendResponse = resp
+	return conv, nil
+}
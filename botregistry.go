@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// BotListRefreshInterval is how often the bot registry is refreshed from the server while connected.
+const BotListRefreshInterval = 24 * time.Hour
+
+// botListRefreshCheckInterval is how often the refresh loop checks whether the bot registry is stale.
+const botListRefreshCheckInterval = 1 * time.Hour
+
+func (cli *Client) updateBotRegistry(ctx context.Context, bots []types.BotListInfo) {
+	if len(bots) == 0 {
+		// Don't wipe the registry if the server didn't return anything
+		return
+	}
+	types.RegisterBots(bots)
+	cli.botRegistryLock.Lock()
+	cli.botRegistryUpdatedAt = time.Now()
+	cli.botRegistryLock.Unlock()
+	if cli.Store.Bots == nil {
+		return
+	}
+	err := cli.Store.Bots.PutBots(ctx, bots)
+	if err != nil {
+		cli.Log.Warnf("Failed to store bot list: %v", err)
+	}
+}
+
+func (cli *Client) loadStoredBotRegistry(ctx context.Context) time.Time {
+	cli.botRegistryLock.Lock()
+	defer cli.botRegistryLock.Unlock()
+	if !cli.botRegistryUpdatedAt.IsZero() || cli.Store.Bots == nil {
+		return cli.botRegistryUpdatedAt
+	}
+	bots, updatedAt, err := cli.Store.Bots.GetBots(ctx)
+	if err != nil {
+		cli.Log.Warnf("Failed to load stored bot list: %v", err)
+	} else if len(bots) > 0 {
+		types.RegisterBots(bots)
+		cli.botRegistryUpdatedAt = updatedAt
+		cli.Log.Debugf("Loaded %d bots from the store", len(bots))
+	}
+	return cli.botRegistryUpdatedAt
+}
+
+func (cli *Client) refreshBotRegistryIfStale(ctx context.Context) {
+	if cli.DisableBotListRefresh {
+		return
+	}
+	updatedAt := cli.loadStoredBotRegistry(ctx)
+	if time.Since(updatedAt) < BotListRefreshInterval || !cli.IsLoggedIn() {
+		return
+	}
+	// GetBotListV2 updates the registry automatically
+	bots, err := cli.GetBotListV2(ctx)
+	if err != nil {
+		cli.Log.Warnf("Failed to refresh bot list: %v", err)
+	} else {
+		cli.Log.Debugf("Refreshed bot list with %d bots", len(bots))
+	}
+}
+
+func (cli *Client) botRegistryRefreshLoop(ctx, connCtx context.Context) {
+	ticker := time.NewTicker(botListRefreshCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			cli.refreshBotRegistryIfStale(connCtx)
+		case <-ctx.Done():
+			return
+		case <-connCtx.Done():
+			return
+		}
+	}
+}
@@ -10,18 +10,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
 	"go.mau.fi/whatsmeow/types"
 )
 
-func (cli *Client) getBroadcastListParticipants(ctx context.Context, jid types.JID) ([]types.JID, error) {
+func (cli *Client) getBroadcastListParticipants(ctx context.Context, jid types.JID, override []types.JID) ([]types.JID, error) {
 	var list []types.JID
 	var err error
-	if jid == types.StatusBroadcastJID {
+	if override != nil {
+		list = slices.Clone(override)
+	} else if jid == types.StatusBroadcastJID {
 		list, err = cli.getStatusBroadcastRecipients(ctx)
 	} else {
-		return nil, ErrBroadcastListUnsupported
+		list, err = cli.getBroadcastListRecipients(ctx, jid)
 	}
 	if err != nil {
 		return nil, err
@@ -44,12 +48,68 @@ func (cli *Client) getBroadcastListParticipants(ctx context.Context, jid types.J
 	return list, nil
 }
 
+func broadcastListFromAction(jid types.JID, act *waSyncAction.BusinessBroadcastListAction) types.BroadcastList {
+	list := types.BroadcastList{
+		JID:        jid,
+		Name:       act.GetListName(),
+		Recipients: make([]types.BroadcastRecipient, 0, len(act.GetParticipants())),
+		LabelIDs:   act.GetLabelIDs(),
+	}
+	for _, participant := range act.GetParticipants() {
+		var recipient types.BroadcastRecipient
+		recipient.LID, _ = types.ParseJID(participant.GetLidJID())
+		if participant.PnJID != nil {
+			recipient.PN, _ = types.ParseJID(participant.GetPnJID())
+		}
+		if recipient.LID.IsEmpty() && recipient.PN.IsEmpty() {
+			continue
+		}
+		list.Recipients = append(list.Recipients, recipient)
+	}
+	return list
+}
+
+func (cli *Client) getBroadcastListRecipients(ctx context.Context, jid types.JID) ([]types.JID, error) {
+	if cli.Store.BroadcastLists == nil {
+		return nil, ErrBroadcastListUnsupported
+	}
+	list, err := cli.Store.BroadcastLists.GetBroadcastList(ctx, jid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast list from db: %w", err)
+	} else if list == nil {
+		return nil, ErrBroadcastListNotFound
+	}
+	recipients := make([]types.JID, 0, len(list.Recipients))
+	for _, recipient := range list.Recipients {
+		// Prefer phone numbers to match the addressing used for status broadcasts
+		if !recipient.PN.IsEmpty() {
+			recipients = append(recipients, recipient.PN)
+		} else {
+			recipients = append(recipients, recipient.LID)
+		}
+	}
+	return recipients, nil
+}
+
+// GetBroadcastLists returns all broadcast lists that have been synced from app state.
+func (cli *Client) GetBroadcastLists(ctx context.Context) ([]*types.BroadcastList, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	} else if cli.Store.BroadcastLists == nil {
+		return nil, ErrBroadcastListUnsupported
+	}
+	return cli.Store.BroadcastLists.GetAllBroadcastLists(ctx)
+}
+
 func (cli *Client) getStatusBroadcastRecipients(ctx context.Context) ([]types.JID, error) {
 	statusPrivacyOptions, err := cli.GetStatusPrivacy(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get status privacy: %w", err)
 	}
-	statusPrivacy := statusPrivacyOptions[0]
+	return cli.getStatusAudienceRecipients(ctx, statusPrivacyOptions[0])
+}
+
+func (cli *Client) getStatusAudienceRecipients(ctx context.Context, statusPrivacy types.StatusPrivacy) ([]types.JID, error) {
 	if statusPrivacy.Type == types.StatusPrivacyTypeWhitelist {
 		// Whitelist mode, just return the list
 		return statusPrivacy.List, nil
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package builder contains fluent builders for WhatsApp messages.
+//
+// For example, to reply to a message with a mention:
+//
+//	msg := builder.Text("Hi @1234567890").
+//		Mention(types.NewJID("1234567890", types.DefaultUserServer)).
+//		ReplyTo(evt, chatTimer).
+//		Build()
+//	resp, err := cli.SendMessage(ctx, evt.Info.Chat, msg)
+package builder
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"go.mau.fi/whatsmeow/content"
+	"go.mau.fi/whatsmeow/formatting"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// MessageBuilder builds a single message. The methods modify the builder in place and return it for chaining.
+type MessageBuilder struct {
+	msg      *waE2E.Message
+	mentions []types.JID
+	// If true, mentions are kept even if their @number isn't in the text
+	keepAllMentions bool
+	ctxInfo         *waE2E.ContextInfo
+	viewOnce        bool
+}
+
+// Text starts building a text message.
+func Text(text string) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{Text: proto.String(text)},
+	}}
+}
+
+// From starts building based on an existing message. The message is cloned, so the original isn't modified.
+func From(msg *waE2E.Message) *MessageBuilder {
+	msg = proto.Clone(msg).(*waE2E.Message)
+	if msg.Conversation != nil {
+		// Plain conversation messages can't have a context info
+		msg.ExtendedTextMessage = &waE2E.ExtendedTextMessage{Text: msg.Conversation}
+		msg.Conversation = nil
+	}
+	b := &MessageBuilder{msg: msg}
+	if existing := findContextInfo(msg, false); existing != nil {
+		b.ctxInfo = existing
+		for _, mention := range existing.GetMentionedJID() {
+			if jid, err := types.ParseJID(mention); err == nil {
+				b.mentions = append(b.mentions, jid)
+			}
+		}
+	}
+	return b
+}
+
+// Forward builds a forwarded copy of the given message.
+//
+// Wrappers like ephemeral and view once are removed, the reply info is dropped and the forwarding score is incremented.
+func Forward(msg *waE2E.Message) *MessageBuilder {
+	inner, _ := content.Unwrap(msg)
+	b := From(inner)
+	b.msg.MessageContextInfo = nil
+	var prevScore uint32
+	if b.ctxInfo != nil {
+		prevScore = b.ctxInfo.GetForwardingScore()
+		mentions := b.ctxInfo.MentionedJID
+		proto.Reset(b.ctxInfo)
+		b.ctxInfo.MentionedJID = mentions
+	}
+	b.keepAllMentions = true
+	return b.Forwarded(prevScore + 1)
+}
+
+// contextInfo returns the context info of the message, creating it if necessary.
+func (b *MessageBuilder) contextInfo() *waE2E.ContextInfo {
+	if b.ctxInfo == nil {
+		b.ctxInfo = &waE2E.ContextInfo{}
+	}
+	return b.ctxInfo
+}
+
+// Mention adds the given users to the mentions of the message.
+//
+// When building, mentions whose @number isn't in the text or caption are dropped
+// to keep ContextInfo.MentionedJID in sync with the text.
+func (b *MessageBuilder) Mention(jids ...types.JID) *MessageBuilder {
+	b.mentions = append(b.mentions, jids...)
+	return b
+}
+
+// ReplyTo makes the message a reply to the given message.
+//
+// The chatTimer parameter is the current disappearing message timer of the chat (see Expiration), or 0 if disappearing
+// messages are disabled. The timer of the replied-to message isn't used, as it may have been sent with an older timer.
+func (b *MessageBuilder) ReplyTo(evt *events.Message, chatTimer time.Duration) *MessageBuilder {
+	return b.ReplyToInfo(&evt.Info, evt.Message).Expiration(chatTimer)
+}
+
+// ReplyToInfo makes the message a reply to the message with the given info and content.
+func (b *MessageBuilder) ReplyToInfo(info *types.MessageInfo, quoted *waE2E.Message) *MessageBuilder {
+	ctxInfo := b.contextInfo()
+	ctxInfo.StanzaID = proto.String(info.ID)
+	ctxInfo.Participant = proto.String(info.Sender.ToNonAD().String())
+	if info.Chat.Server == types.BroadcastServer {
+		ctxInfo.RemoteJID = proto.String(info.Chat.String())
+	}
+	if quoted != nil {
+		quoted, _ = content.Unwrap(quoted)
+		quoted = proto.Clone(quoted).(*waE2E.Message)
+		quoted.MessageContextInfo = nil
+		// Clients only include one level of quotes
+		if quotedCtx := findContextInfo(quoted, false); quotedCtx != nil {
+			quotedCtx.QuotedMessage = nil
+			quotedCtx.StanzaID = nil
+			quotedCtx.Participant = nil
+			quotedCtx.RemoteJID = nil
+		}
+		ctxInfo.QuotedMessage = quoted
+	}
+	return b
+}
+
+// Expiration sets the disappearing message timer of the message. This should match the chat's current timer
+// (types.GroupInfo.DisappearingTimer for groups).
+func (b *MessageBuilder) Expiration(timer time.Duration) *MessageBuilder {
+	if timer <= 0 {
+		b.contextInfo().Expiration = nil
+	} else {
+		b.contextInfo().Expiration = proto.Uint32(uint32(timer.Seconds()))
+	}
+	return b
+}
+
+// Forwarded marks the message as forwarded with the given forwarding score.
+// Clients show "forwarded many times" when the score is 5 or higher.
+func (b *MessageBuilder) Forwarded(score uint32) *MessageBuilder {
+	ctxInfo := b.contextInfo()
+	ctxInfo.IsForwarded = proto.Bool(true)
+	ctxInfo.ForwardingScore = proto.Uint32(score)
+	return b
+}
+
+// ViewOnce wraps the message in a view once message. This is only supported for images, videos and voice messages.
+func (b *MessageBuilder) ViewOnce() *MessageBuilder {
+	b.viewOnce = true
+	return b
+}
+
+func (b *MessageBuilder) text() string {
+	c := content.Parse(b.msg)
+	return c.Body
+}
+
+// Build returns the built message.
+func (b *MessageBuilder) Build() *waE2E.Message {
+	msg := b.msg
+	if len(b.mentions) > 0 {
+		mentions := b.mentions
+		if !b.keepAllMentions {
+			mentions = formatting.FilterMentions(b.text(), mentions)
+		}
+		mentionStrings := make([]string, len(mentions))
+		for i, jid := range mentions {
+			mentionStrings[i] = jid.String()
+		}
+		b.contextInfo().MentionedJID = mentionStrings
+	} else if b.ctxInfo != nil {
+		b.ctxInfo.MentionedJID = nil
+	}
+	if b.ctxInfo != nil && !proto.Equal(b.ctxInfo, &waE2E.ContextInfo{}) {
+		setContextInfo(msg, b.ctxInfo)
+	}
+	if b.viewOnce {
+		msg = &waE2E.Message{ViewOnceMessage: &waE2E.FutureProofMessage{Message: msg}}
+	}
+	return msg
+}
+
+var contextInfoDescriptor = (&waE2E.ContextInfo{}).ProtoReflect().Descriptor()
+
+// findContextInfo finds the context info field of the first submessage that has one.
+func findContextInfo(msg *waE2E.Message, create bool) (found *waE2E.ContextInfo) {
+	if msg == nil {
+		return nil
+	}
+	msg.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return true
+		}
+		ctxField := fd.Message().Fields().ByName("contextInfo")
+		if ctxField == nil || ctxField.Message() == nil || ctxField.Message().FullName() != contextInfoDescriptor.FullName() {
+			return true
+		}
+		sub := value.Message()
+		if sub.Has(ctxField) {
+			found = sub.Get(ctxField).Message().Interface().(*waE2E.ContextInfo)
+		} else if create {
+			found = &waE2E.ContextInfo{}
+			sub.Set(ctxField, protoreflect.ValueOfMessage(found.ProtoReflect()))
+		}
+		return false
+	})
+	return
+}
+
+func setContextInfo(msg *waE2E.Message, ctxInfo *waE2E.ContextInfo) {
+	target := findContextInfo(msg, true)
+	if target != nil && target != ctxInfo {
+		proto.Merge(target, ctxInfo)
+	}
+}
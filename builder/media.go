@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package builder
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+// Image starts building an image message from an uploaded file (see whatsmeow.Client.Upload with whatsmeow.MediaImage).
+func Image(upload whatsmeow.UploadResponse, mimetype string) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		URL:           proto.String(upload.URL),
+		DirectPath:    proto.String(upload.DirectPath),
+		MediaKey:      upload.MediaKey,
+		FileEncSHA256: upload.FileEncSHA256,
+		FileSHA256:    upload.FileSHA256,
+		FileLength:    proto.Uint64(upload.FileLength),
+		Mimetype:      proto.String(mimetype),
+	}}}
+}
+
+// Video starts building a video message from an uploaded file (see whatsmeow.Client.Upload with whatsmeow.MediaVideo).
+func Video(upload whatsmeow.UploadResponse, mimetype string, duration time.Duration) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+		URL:           proto.String(upload.URL),
+		DirectPath:    proto.String(upload.DirectPath),
+		MediaKey:      upload.MediaKey,
+		FileEncSHA256: upload.FileEncSHA256,
+		FileSHA256:    upload.FileSHA256,
+		FileLength:    proto.Uint64(upload.FileLength),
+		Mimetype:      proto.String(mimetype),
+		Seconds:       proto.Uint32(uint32(duration.Seconds())),
+	}}}
+}
+
+// Audio starts building an audio message from an uploaded file (see whatsmeow.Client.Upload with whatsmeow.MediaAudio).
+//
+// If ptt is true, the message is sent as a voice message. Voice messages should be Opus in an Ogg container.
+func Audio(upload whatsmeow.UploadResponse, mimetype string, duration time.Duration, ptt bool) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{AudioMessage: &waE2E.AudioMessage{
+		URL:           proto.String(upload.URL),
+		DirectPath:    proto.String(upload.DirectPath),
+		MediaKey:      upload.MediaKey,
+		FileEncSHA256: upload.FileEncSHA256,
+		FileSHA256:    upload.FileSHA256,
+		FileLength:    proto.Uint64(upload.FileLength),
+		Mimetype:      proto.String(mimetype),
+		Seconds:       proto.Uint32(uint32(duration.Seconds())),
+		PTT:           proto.Bool(ptt),
+	}}}
+}
+
+// Document starts building a document message from an uploaded file (see whatsmeow.Client.Upload with whatsmeow.MediaDocument).
+func Document(upload whatsmeow.UploadResponse, mimetype, fileName string) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+		URL:           proto.String(upload.URL),
+		DirectPath:    proto.String(upload.DirectPath),
+		MediaKey:      upload.MediaKey,
+		FileEncSHA256: upload.FileEncSHA256,
+		FileSHA256:    upload.FileSHA256,
+		FileLength:    proto.Uint64(upload.FileLength),
+		Mimetype:      proto.String(mimetype),
+		FileName:      proto.String(fileName),
+		Title:         proto.String(fileName),
+	}}}
+}
+
+// Sticker starts building a sticker message from an uploaded WebP file (see whatsmeow.Client.Upload with whatsmeow.MediaImage).
+func Sticker(upload whatsmeow.UploadResponse, width, height uint32, animated bool) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{StickerMessage: &waE2E.StickerMessage{
+		URL:           proto.String(upload.URL),
+		DirectPath:    proto.String(upload.DirectPath),
+		MediaKey:      upload.MediaKey,
+		FileEncSHA256: upload.FileEncSHA256,
+		FileSHA256:    upload.FileSHA256,
+		FileLength:    proto.Uint64(upload.FileLength),
+		Mimetype:      proto.String("image/webp"),
+		Width:         proto.Uint32(width),
+		Height:        proto.Uint32(height),
+		IsAnimated:    proto.Bool(animated),
+	}}}
+}
+
+// Caption sets the caption of an image, video or document message.
+func (b *MessageBuilder) Caption(caption string) *MessageBuilder {
+	switch {
+	case b.msg.ImageMessage != nil:
+		b.msg.ImageMessage.Caption = proto.String(caption)
+	case b.msg.VideoMessage != nil:
+		b.msg.VideoMessage.Caption = proto.String(caption)
+	case b.msg.DocumentMessage != nil:
+		b.msg.DocumentMessage.Caption = proto.String(caption)
+	}
+	return b
+}
+
+// Thumbnail sets the JPEG thumbnail of an image, video or document message.
+func (b *MessageBuilder) Thumbnail(jpeg []byte) *MessageBuilder {
+	switch {
+	case b.msg.ImageMessage != nil:
+		b.msg.ImageMessage.JPEGThumbnail = jpeg
+	case b.msg.VideoMessage != nil:
+		b.msg.VideoMessage.JPEGThumbnail = jpeg
+	case b.msg.DocumentMessage != nil:
+		b.msg.DocumentMessage.JPEGThumbnail = jpeg
+	}
+	return b
+}
+
+// Dimensions sets the width and height of an image or video message.
+func (b *MessageBuilder) Dimensions(width, height uint32) *MessageBuilder {
+	switch {
+	case b.msg.ImageMessage != nil:
+		b.msg.ImageMessage.Width = proto.Uint32(width)
+		b.msg.ImageMessage.Height = proto.Uint32(height)
+	case b.msg.VideoMessage != nil:
+		b.msg.VideoMessage.Width = proto.Uint32(width)
+		b.msg.VideoMessage.Height = proto.Uint32(height)
+	}
+	return b
+}
+
+// GIF marks a video message to be played like a GIF (looping and without sound).
+func (b *MessageBuilder) GIF() *MessageBuilder {
+	if b.msg.VideoMessage != nil {
+		b.msg.VideoMessage.GifPlayback = proto.Bool(true)
+	}
+	return b
+}
+
+// Album starts building an album message, which groups the following images and videos in clients.
+//
+// After sending the album message, build each item with InAlbum using the key of the sent album message.
+func Album(expectedImages, expectedVideos uint32) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{AlbumMessage: &waE2E.AlbumMessage{
+		ExpectedImageCount: proto.Uint32(expectedImages),
+		ExpectedVideoCount: proto.Uint32(expectedVideos),
+	}}}
+}
+
+// InAlbum marks the message as an item in the album with the given key. Use types.NewMessageKey to build the key.
+func (b *MessageBuilder) InAlbum(album *waCommon.MessageKey) *MessageBuilder {
+	if b.msg.MessageContextInfo == nil {
+		b.msg.MessageContextInfo = &waE2E.MessageContextInfo{}
+	}
+	b.msg.MessageContextInfo.MessageAssociation = &waE2E.MessageAssociation{
+		AssociationType:  waE2E.MessageAssociation_MEDIA_ALBUM.Enum(),
+		ParentMessageKey: album,
+	}
+	return b
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package builder
+
+import (
+	"strings"
+	"time"
+
+	"go.mau.fi/util/random"
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+// Location starts building a static location message.
+func Location(latitude, longitude float64) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{LocationMessage: &waE2E.LocationMessage{
+		DegreesLatitude:  proto.Float64(latitude),
+		DegreesLongitude: proto.Float64(longitude),
+	}}}
+}
+
+// Place sets the name, address and URL of a location message.
+func (b *MessageBuilder) Place(name, address, url string) *MessageBuilder {
+	if loc := b.msg.LocationMessage; loc != nil {
+		loc.Name = proto.String(name)
+		loc.Address = proto.String(address)
+		if url != "" {
+			loc.URL = proto.String(url)
+		}
+	}
+	return b
+}
+
+// LiveLocation starts building a live location message.
+//
+// The first message should have sequence number 0. Updates are sent as new live location messages
+// with an incremented sequence number.
+func LiveLocation(latitude, longitude float64, sequenceNumber int64) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{LiveLocationMessage: &waE2E.LiveLocationMessage{
+		DegreesLatitude:  proto.Float64(latitude),
+		DegreesLongitude: proto.Float64(longitude),
+		SequenceNumber:   proto.Int64(sequenceNumber),
+	}}}
+}
+
+// Accuracy sets the accuracy of a location or live location message.
+func (b *MessageBuilder) Accuracy(meters uint32) *MessageBuilder {
+	switch {
+	case b.msg.LocationMessage != nil:
+		b.msg.LocationMessage.AccuracyInMeters = proto.Uint32(meters)
+	case b.msg.LiveLocationMessage != nil:
+		b.msg.LiveLocationMessage.AccuracyInMeters = proto.Uint32(meters)
+	}
+	return b
+}
+
+// Movement sets the speed and heading of a live location message.
+func (b *MessageBuilder) Movement(speedMPS float32, heading uint32) *MessageBuilder {
+	if loc := b.msg.LiveLocationMessage; loc != nil {
+		loc.SpeedInMps = proto.Float32(speedMPS)
+		loc.DegreesClockwiseFromMagneticNorth = proto.Uint32(heading)
+	}
+	return b
+}
+
+// Comment sets the caption of a live location message or the comment of a static location message.
+func (b *MessageBuilder) Comment(comment string) *MessageBuilder {
+	switch {
+	case b.msg.LocationMessage != nil:
+		b.msg.LocationMessage.Comment = proto.String(comment)
+	case b.msg.LiveLocationMessage != nil:
+		b.msg.LiveLocationMessage.Caption = proto.String(comment)
+	}
+	return b
+}
+
+// VCard builds a minimal vCard with a name and WhatsApp phone number (in international format without the +).
+func VCard(name, phone string) string {
+	escapedName := strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`).Replace(name)
+	return "BEGIN:VCARD\n" +
+		"VERSION:3.0\n" +
+		"FN:" + escapedName + "\n" +
+		"TEL;type=CELL;waid=" + phone + ":+" + phone + "\n" +
+		"END:VCARD"
+}
+
+// ContactCard is a single contact for Contacts.
+type ContactCard struct {
+	DisplayName string
+	VCard       string
+}
+
+// Contacts starts building a contact message. If more than one contact is given, a contacts array message is built
+// with the given display name. Otherwise, the display name is ignored.
+func Contacts(displayName string, contacts ...ContactCard) *MessageBuilder {
+	if len(contacts) == 1 {
+		return &MessageBuilder{msg: &waE2E.Message{ContactMessage: &waE2E.ContactMessage{
+			DisplayName: proto.String(contacts[0].DisplayName),
+			Vcard:       proto.String(contacts[0].VCard),
+		}}}
+	}
+	arr := &waE2E.ContactsArrayMessage{
+		DisplayName: proto.String(displayName),
+		Contacts:    make([]*waE2E.ContactMessage, len(contacts)),
+	}
+	for i, contact := range contacts {
+		arr.Contacts[i] = &waE2E.ContactMessage{
+			DisplayName: proto.String(contact.DisplayName),
+			Vcard:       proto.String(contact.VCard),
+		}
+	}
+	return &MessageBuilder{msg: &waE2E.Message{ContactsArrayMessage: arr}}
+}
+
+// Event starts building an event message.
+//
+// Responses to events are encrypted like poll votes, so the message includes a random message secret.
+func Event(name string, start time.Time) *MessageBuilder {
+	return &MessageBuilder{msg: &waE2E.Message{
+		EventMessage: &waE2E.EventMessage{
+			Name:               proto.String(name),
+			StartTime:          proto.Int64(start.Unix()),
+			IsCanceled:         proto.Bool(false),
+			ExtraGuestsAllowed: proto.Bool(false),
+		},
+		MessageContextInfo: &waE2E.MessageContextInfo{
+			MessageSecret: random.Bytes(32),
+		},
+	}}
+}
+
+// EventDetails sets the description, end time and join link of an event message. Empty values are left unset.
+func (b *MessageBuilder) EventDetails(description string, end time.Time, joinLink string) *MessageBuilder {
+	if evt := b.msg.EventMessage; evt != nil {
+		if description != "" {
+			evt.Description = proto.String(description)
+		}
+		if !end.IsZero() {
+			evt.EndTime = proto.Int64(end.Unix())
+		}
+		if joinLink != "" {
+			evt.JoinLink = proto.String(joinLink)
+		}
+	}
+	return b
+}
+
+// EventLocation sets the location of an event message.
+func (b *MessageBuilder) EventLocation(latitude, longitude float64, name string) *MessageBuilder {
+	if evt := b.msg.EventMessage; evt != nil {
+		evt.Location = &waE2E.LocationMessage{
+			DegreesLatitude:  proto.Float64(latitude),
+			DegreesLongitude: proto.Float64(longitude),
+			Name:             proto.String(name),
+		}
+	}
+	return b
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+)
+
+const catalogNamespace = "w:biz:catalog"
+
+// DefaultCatalogImageSize is the default width and height of product images requested in GetCatalog and GetProductCollections.
+const DefaultCatalogImageSize = 100
+
+// BusinessHours contains the operating hours of a business for UpdateBusinessProfile.
+type BusinessHours struct {
+	TimeZone string
+	Config   []types.BusinessHoursConfig
+}
+
+// UpdateBusinessProfileParams contains the changes to make to the business profile in UpdateBusinessProfile.
+//
+// Nil fields are left unchanged. To clear a field, set it to an empty value (e.g. an empty non-nil slice).
+type UpdateBusinessProfileParams struct {
+	Description *string
+	Address     *string
+	Email       *string
+	// Up to two websites.
+	Websites []string
+	// The IDs of the business categories (see types.Category).
+	Categories    []string
+	BusinessHours *BusinessHours
+}
+
+// GetCatalogParams contains the parameters for GetCatalog and GetProductCollections.
+type GetCatalogParams struct {
+	// The maximum number of products (or collections) to return. Defaults to 10.
+	Limit int
+	// The maximum number of products to return in each collection. Only used in GetProductCollections. Defaults to 10.
+	ItemLimit int
+	// The cursor from a previous Catalog.NextCursor to get the next page.
+	After string
+	// The size of the product images to request. Defaults to DefaultCatalogImageSize.
+	ImageWidth, ImageHeight int
+}
+
+func textNode(tag, content string) waBinary.Node {
+	return waBinary.Node{Tag: tag, Content: []byte(content)}
+}
+
+func childText(node waBinary.Node, tag string) string {
+	child, _ := node.GetOptionalChildByTag(tag)
+	content, _ := child.Content.([]byte)
+	return string(content)
+}
+
+// UpdateBusinessProfile updates the business profile of the current user.
+//
+// Use GetBusinessProfile to get the current profile.
+func (cli *Client) UpdateBusinessProfile(ctx context.Context, params UpdateBusinessProfileParams) error {
+	var content []waBinary.Node
+	if params.Description != nil {
+		content = append(content, textNode("description", *params.Description))
+	}
+	if params.Address != nil {
+		content = append(content, textNode("address", *params.Address))
+	}
+	if params.Email != nil {
+		content = append(content, textNode("email", *params.Email))
+	}
+	if params.Websites != nil {
+		if len(params.Websites) == 0 {
+			content = append(content, waBinary.Node{Tag: "website"})
+		}
+		for _, website := range params.Websites {
+			content = append(content, textNode("website", website))
+		}
+	}
+	if params.Categories != nil {
+		categories := make([]waBinary.Node, len(params.Categories))
+		for i, id := range params.Categories {
+			categories[i] = waBinary.Node{Tag: "category", Attrs: waBinary.Attrs{"id": id}}
+		}
+		content = append(content, waBinary.Node{Tag: "categories", Content: categories})
+	}
+	if params.BusinessHours != nil {
+		configs := make([]waBinary.Node, len(params.BusinessHours.Config))
+		for i, cfg := range params.BusinessHours.Config {
+			attrs := waBinary.Attrs{
+				"day_of_week": cfg.DayOfWeek,
+				"mode":        cfg.Mode,
+			}
+			// Open and close times are only used in the specific_hours mode
+			if cfg.OpenTime != "" {
+				attrs["open_time"] = cfg.OpenTime
+			}
+			if cfg.CloseTime != "" {
+				attrs["close_time"] = cfg.CloseTime
+			}
+			configs[i] = waBinary.Node{Tag: "business_hours_config", Attrs: attrs}
+		}
+		content = append(content, waBinary.Node{
+			Tag:     "business_hours",
+			Attrs:   waBinary.Attrs{"timezone": params.BusinessHours.TimeZone},
+			Content: configs,
+		})
+	}
+	_, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: "w:biz",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "business_profile",
+			Attrs: waBinary.Attrs{
+				"v":             "3",
+				"mutation_type": "delta",
+			},
+			Content: content,
+		}},
+	})
+	return err
+}
+
+func (params *GetCatalogParams) limitNodes(collections bool) []waBinary.Node {
+	limit, itemLimit, width, height := params.Limit, params.ItemLimit, params.ImageWidth, params.ImageHeight
+	if limit <= 0 {
+		limit = 10
+	}
+	if itemLimit <= 0 {
+		itemLimit = 10
+	}
+	if width <= 0 {
+		width = DefaultCatalogImageSize
+	}
+	if height <= 0 {
+		height = DefaultCatalogImageSize
+	}
+	var nodes []waBinary.Node
+	if collections {
+		nodes = []waBinary.Node{
+			textNode("collection_limit", strconv.Itoa(limit)),
+			textNode("item_limit", strconv.Itoa(itemLimit)),
+		}
+	} else {
+		nodes = []waBinary.Node{textNode("limit", strconv.Itoa(limit))}
+	}
+	nodes = append(nodes,
+		textNode("width", strconv.Itoa(width)),
+		textNode("height", strconv.Itoa(height)),
+	)
+	if params.After != "" {
+		nodes = append(nodes, textNode("after", params.After))
+	}
+	return nodes
+}
+
+func parseProduct(node waBinary.Node) (*types.Product, error) {
+	product := &types.Product{
+		ID:           childText(node, "id"),
+		RetailerID:   childText(node, "retailer_id"),
+		Name:         childText(node, "name"),
+		Description:  childText(node, "description"),
+		URL:          childText(node, "url"),
+		Currency:     childText(node, "currency"),
+		Hidden:       node.AttrGetter().OptionalBool("is_hidden"),
+		Availability: childText(node, "availability"),
+	}
+	if price := childText(node, "price"); price != "" {
+		var err error
+		product.PriceAmount1000, err = strconv.ParseInt(price, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse price of product %s: %w", product.ID, err)
+		}
+	}
+	statusInfo, _ := node.GetOptionalChildByTag("status_info")
+	product.ReviewStatus = childText(statusInfo, "status")
+	compliance, _ := node.GetOptionalChildByTag("compliance_info")
+	product.OriginCountryCode = childText(compliance, "country_code_origin")
+	media, _ := node.GetOptionalChildByTag("media")
+	for _, img := range media.GetChildrenByTag("image") {
+		url := childText(img, "request_image_url")
+		if url == "" {
+			url = childText(img, "url")
+		}
+		product.Images = append(product.Images, types.ProductImage{
+			URL:         url,
+			OriginalURL: childText(img, "original_image_url"),
+		})
+	}
+	return product, nil
+}
+
+func parseProducts(nodes []waBinary.Node) ([]*types.Product, error) {
+	products := make([]*types.Product, 0, len(nodes))
+	for _, child := range nodes {
+		if child.Tag != "product" {
+			continue
+		}
+		product, err := parseProduct(child)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// GetCatalog gets a page of products in the catalog of the given business.
+//
+// Products can be created and edited with the functions in the business package.
+func (cli *Client) GetCatalog(ctx context.Context, owner types.JID, params GetCatalogParams) (*types.Catalog, error) {
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: catalogNamespace,
+		Type:      iqGet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "product_catalog",
+			Attrs: waBinary.Attrs{
+				"jid":               owner,
+				"allow_shop_source": "true",
+			},
+			Content: params.limitNodes(false),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	catalogNode, ok := resp.GetOptionalChildByTag("product_catalog")
+	if !ok {
+		return nil, &ElementMissingError{Tag: "product_catalog", In: "response to catalog query"}
+	}
+	products, err := parseProducts(catalogNode.GetChildren())
+	if err != nil {
+		return nil, err
+	}
+	paging, _ := catalogNode.GetOptionalChildByTag("paging")
+	return &types.Catalog{
+		Products:   products,
+		NextCursor: childText(paging, "after"),
+	}, nil
+}
+
+func parseProductCollection(node waBinary.Node) (*types.ProductCollection, error) {
+	products, err := parseProducts(node.GetChildren())
+	if err != nil {
+		return nil, err
+	}
+	statusInfo, _ := node.GetOptionalChildByTag("status_info")
+	return &types.ProductCollection{
+		ID:           childText(node, "id"),
+		Name:         childText(node, "name"),
+		Products:     products,
+		ReviewStatus: childText(statusInfo, "status"),
+	}, nil
+}
+
+// GetProductCollections gets the product collections in the catalog of the given business.
+func (cli *Client) GetProductCollections(ctx context.Context, owner types.JID, params GetCatalogParams) ([]*types.ProductCollection, error) {
+	resp, err := cli.sendIQ(ctx, infoQuery{
+		Namespace: catalogNamespace,
+		Type:      iqGet,
+		To:        types.ServerJID,
+		SMaxID:    "35",
+		Content: []waBinary.Node{{
+			Tag:     "collections",
+			Attrs:   waBinary.Attrs{"biz_jid": owner},
+			Content: params.limitNodes(true),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	collectionsNode, ok := resp.GetOptionalChildByTag("collections")
+	if !ok {
+		return nil, &ElementMissingError{Tag: "collections", In: "response to collections query"}
+	}
+	collectionNodes := collectionsNode.GetChildrenByTag("collection")
+	collections := make([]*types.ProductCollection, len(collectionNodes))
+	for i, node := range collectionNodes {
+		collections[i], err = parseProductCollection(node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return collections, nil
+}
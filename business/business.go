@@ -0,0 +1,21 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package business contains APIs for managing the product catalog of WhatsApp Business accounts
+// and building product and catalog messages.
+//
+// Catalog and collection mutations are sent as mex (GraphQL) operations using whatsmeow.SendMexOperation.
+//
+// Reading business profiles and catalogs and editing the business profile are available in the main package
+// (whatsmeow.Client.GetBusinessProfile, UpdateBusinessProfile, GetCatalog and GetProductCollections).
+package business
+
+import (
+	"errors"
+)
+
+// ErrEmptyResponse is returned by catalog mutations if the server didn't return the mutated entity.
+var ErrEmptyResponse = errors.New("server returned an empty response")
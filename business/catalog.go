@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// CreateProduct adds a product to the catalog of the current user. The returned product contains the ID assigned by WhatsApp.
+func CreateProduct(ctx context.Context, cli *whatsmeow.Client, product *types.Product) (*types.Product, error) {
+	resp, err := whatsmeow.SendMexOperation(ctx, cli, mexAddProduct, mexProductVars{Input: productToMex(product, false)})
+	if err != nil {
+		return nil, err
+	} else if resp.Product == nil {
+		return nil, fmt.Errorf("%w for added product", ErrEmptyResponse)
+	}
+	return resp.Product.toProduct(), nil
+}
+
+// EditProduct replaces the details of the product with the ID in the given product.
+func EditProduct(ctx context.Context, cli *whatsmeow.Client, product *types.Product) (*types.Product, error) {
+	if product.ID == "" {
+		return nil, fmt.Errorf("product ID must be set when editing products")
+	}
+	resp, err := whatsmeow.SendMexOperation(ctx, cli, mexEditProduct, mexProductVars{Input: productToMex(product, true)})
+	if err != nil {
+		return nil, err
+	} else if resp.Product == nil {
+		return nil, fmt.Errorf("%w for edited product", ErrEmptyResponse)
+	}
+	return resp.Product.toProduct(), nil
+}
+
+// DeleteProducts deletes the given products from the catalog of the current user and returns the number of deleted products.
+func DeleteProducts(ctx context.Context, cli *whatsmeow.Client, productIDs ...string) (int, error) {
+	resp, err := whatsmeow.SendMexOperation(ctx, cli, mexDeleteProducts, mexDeleteProductsVars{
+		Input: mexDeleteProductsInput{ProductIDs: productIDs},
+	})
+	if err != nil {
+		return 0, err
+	} else if resp.Result == nil {
+		return 0, fmt.Errorf("%w for deleted products", ErrEmptyResponse)
+	}
+	return resp.Result.DeletedCount, nil
+}
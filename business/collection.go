@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package business
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// CreateCollection creates a new collection in the catalog of the current user.
+// The returned collection contains the ID assigned by WhatsApp.
+func CreateCollection(ctx context.Context, cli *whatsmeow.Client, collection *types.ProductCollection) (*types.ProductCollection, error) {
+	resp, err := whatsmeow.SendMexOperation(ctx, cli, mexCreateCollections, mexCreateCollectionsVars{
+		Input: mexCreateCollectionsInput{Collections: []mexCollection{collectionToMex(collection, false)}},
+	})
+	if err != nil {
+		return nil, err
+	} else if len(resp.Collections) == 0 {
+		return nil, fmt.Errorf("%w for created collection", ErrEmptyResponse)
+	}
+	return resp.Collections[0].toCollection(), nil
+}
+
+// EditCollection replaces the name and products of the collection with the ID in the given collection.
+func EditCollection(ctx context.Context, cli *whatsmeow.Client, collection *types.ProductCollection) (*types.ProductCollection, error) {
+	if collection.ID == "" {
+		return nil, fmt.Errorf("collection ID must be set when editing collections")
+	}
+	resp, err := whatsmeow.SendMexOperation(ctx, cli, mexUpdateCollection, mexCollectionVars{
+		Input: collectionToMex(collection, true),
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Collection == nil {
+		return nil, fmt.Errorf("%w for edited collection", ErrEmptyResponse)
+	}
+	return resp.Collection.toCollection(), nil
+}
+
+// DeleteCollections deletes the given collections from the catalog of the current user.
+// The products in the collections are not deleted.
+func DeleteCollections(ctx context.Context, cli *whatsmeow.Client, collectionIDs ...string) error {
+	for _, id := range collectionIDs {
+		_, err := whatsmeow.SendMexOperation(ctx, cli, mexDeleteCollection, mexDeleteCollectionVars{
+			Input: mexDeleteCollectionInput{CollectionID: id},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete collection %s: %w", id, err)
+		}
+	}
+	return nil
+}
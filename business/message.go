@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package business
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// CatalogLink returns the wa.me link to the catalog of the given business.
+func CatalogLink(owner types.JID) string {
+	return "https://wa.me/c/" + owner.User
+}
+
+// ProductMessage builds a message that shares a product from the catalog of the given business.
+//
+// The image must be an uploaded image message (see whatsmeow.Client.Upload), as the product image URLs
+// from the catalog can't be used in messages directly. The body and footer are optional.
+// The built message can be sent with whatsmeow.Client.SendMessage.
+func ProductMessage(owner types.JID, product *types.Product, image *waE2E.ImageMessage, body, footer string) *waE2E.Message {
+	snapshot := &waE2E.ProductMessage_ProductSnapshot{
+		ProductImage:      image,
+		ProductID:         proto.String(product.ID),
+		Title:             proto.String(product.Name),
+		ProductImageCount: proto.Uint32(uint32(len(product.Images))),
+	}
+	if product.Description != "" {
+		snapshot.Description = proto.String(product.Description)
+	}
+	if product.Currency != "" {
+		snapshot.CurrencyCode = proto.String(product.Currency)
+		snapshot.PriceAmount1000 = proto.Int64(product.PriceAmount1000)
+	}
+	if product.RetailerID != "" {
+		snapshot.RetailerID = proto.String(product.RetailerID)
+	}
+	if product.URL != "" {
+		snapshot.URL = proto.String(product.URL)
+	}
+	msg := &waE2E.ProductMessage{
+		Product:          snapshot,
+		BusinessOwnerJID: proto.String(owner.ToNonAD().String()),
+	}
+	if body != "" {
+		msg.Body = proto.String(body)
+	}
+	if footer != "" {
+		msg.Footer = proto.String(footer)
+	}
+	return &waE2E.Message{ProductMessage: msg}
+}
+
+// CatalogMessage builds a message that shares the catalog of the given business.
+//
+// Like the official apps, the catalog is shared as a text message containing the catalog link (see CatalogLink).
+// The link is appended to the given text, which may be empty.
+func CatalogMessage(owner types.JID, text string) *waE2E.Message {
+	link := CatalogLink(owner)
+	if text != "" {
+		text += "\n\n"
+	}
+	return &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+		Text:        proto.String(text + link),
+		MatchedText: proto.String(link),
+	}}
+}
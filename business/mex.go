@@ -0,0 +1,194 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package business
+
+import (
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+type mexProductImage struct {
+	URL         string `json:"url"`
+	OriginalURL string `json:"original_url,omitempty"`
+}
+
+type mexProduct struct {
+	ID                string            `json:"id,omitempty"`
+	RetailerID        string            `json:"retailer_id,omitempty"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description,omitempty"`
+	URL               string            `json:"url,omitempty"`
+	Price             *int64            `json:"price,omitempty"`
+	Currency          string            `json:"currency,omitempty"`
+	IsHidden          bool              `json:"is_hidden"`
+	Images            []mexProductImage `json:"images,omitempty"`
+	OriginCountryCode string            `json:"origin_country_code,omitempty"`
+
+	// Only present in responses
+	ReviewStatus string `json:"review_status,omitempty"`
+	Availability string `json:"availability,omitempty"`
+}
+
+func productToMex(p *types.Product, edit bool) mexProduct {
+	mp := mexProduct{
+		RetailerID:        p.RetailerID,
+		Name:              p.Name,
+		Description:       p.Description,
+		URL:               p.URL,
+		Currency:          p.Currency,
+		IsHidden:          p.Hidden,
+		OriginCountryCode: p.OriginCountryCode,
+	}
+	if edit {
+		mp.ID = p.ID
+	}
+	if p.Currency != "" {
+		price := p.PriceAmount1000
+		mp.Price = &price
+	}
+	for _, img := range p.Images {
+		mp.Images = append(mp.Images, mexProductImage{URL: img.URL})
+	}
+	return mp
+}
+
+func (mp *mexProduct) toProduct() *types.Product {
+	p := &types.Product{
+		ID:                mp.ID,
+		RetailerID:        mp.RetailerID,
+		Name:              mp.Name,
+		Description:       mp.Description,
+		URL:               mp.URL,
+		Currency:          mp.Currency,
+		Hidden:            mp.IsHidden,
+		OriginCountryCode: mp.OriginCountryCode,
+		ReviewStatus:      mp.ReviewStatus,
+		Availability:      mp.Availability,
+	}
+	if mp.Price != nil {
+		p.PriceAmount1000 = *mp.Price
+	}
+	for _, img := range mp.Images {
+		p.Images = append(p.Images, types.ProductImage{URL: img.URL, OriginalURL: img.OriginalURL})
+	}
+	return p
+}
+
+type mexCollection struct {
+	ID         string   `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	ProductIDs []string `json:"product_ids,omitempty"`
+
+	// Only present in responses
+	Products     []mexProduct `json:"products,omitempty"`
+	ReviewStatus string       `json:"review_status,omitempty"`
+}
+
+func collectionToMex(c *types.ProductCollection, edit bool) mexCollection {
+	mc := mexCollection{Name: c.Name, ProductIDs: make([]string, len(c.Products))}
+	if edit {
+		mc.ID = c.ID
+	}
+	for i, product := range c.Products {
+		mc.ProductIDs[i] = product.ID
+	}
+	return mc
+}
+
+func (mc *mexCollection) toCollection() *types.ProductCollection {
+	c := &types.ProductCollection{
+		ID:           mc.ID,
+		Name:         mc.Name,
+		Products:     make([]*types.Product, len(mc.Products)),
+		ReviewStatus: mc.ReviewStatus,
+	}
+	for i, product := range mc.Products {
+		c.Products[i] = product.toProduct()
+	}
+	return c
+}
+
+type mexProductVars struct {
+	Input mexProduct `json:"input"`
+}
+
+type respAddProduct struct {
+	Product *mexProduct `json:"xwa2_catalog_add_product"`
+}
+
+type respEditProduct struct {
+	Product *mexProduct `json:"xwa2_catalog_edit_product"`
+}
+
+type mexDeleteProductsInput struct {
+	ProductIDs []string `json:"product_ids"`
+}
+
+type mexDeleteProductsVars struct {
+	Input mexDeleteProductsInput `json:"input"`
+}
+
+type respDeleteProducts struct {
+	Result *struct {
+		DeletedCount int `json:"deleted_count"`
+	} `json:"xwa2_catalog_delete_product"`
+}
+
+type mexCreateCollectionsInput struct {
+	Collections []mexCollection `json:"collections"`
+}
+
+type mexCreateCollectionsVars struct {
+	Input mexCreateCollectionsInput `json:"input"`
+}
+
+type respCreateCollections struct {
+	Collections []mexCollection `json:"xwa2_catalog_create_collections"`
+}
+
+type mexCollectionVars struct {
+	Input mexCollection `json:"input"`
+}
+
+type respUpdateCollection struct {
+	Collection *mexCollection `json:"xwa2_catalog_update_collection"`
+}
+
+type mexDeleteCollectionInput struct {
+	CollectionID string `json:"collection_id"`
+}
+
+type mexDeleteCollectionVars struct {
+	Input mexDeleteCollectionInput `json:"input"`
+}
+
+type respDeleteCollection struct {
+	Result *struct {
+		ID string `json:"id"`
+	} `json:"xwa2_catalog_delete_collection"`
+}
+
+var (
+	mexAddProduct = whatsmeow.RegisterMexOperation[mexProductVars, respAddProduct](
+		"WhatsAppCatalogAddProduct", whatsmeow.MexQueryIDs{},
+	)
+	mexEditProduct = whatsmeow.RegisterMexOperation[mexProductVars, respEditProduct](
+		"WhatsAppCatalogEditProduct", whatsmeow.MexQueryIDs{},
+	)
+	mexDeleteProducts = whatsmeow.RegisterMexOperation[mexDeleteProductsVars, respDeleteProducts](
+		"WhatsAppCatalogDeleteProduct", whatsmeow.MexQueryIDs{},
+	)
+	mexCreateCollections = whatsmeow.RegisterMexOperation[mexCreateCollectionsVars, respCreateCollections](
+		"WhatsAppCatalogCreateCollections", whatsmeow.MexQueryIDs{},
+	)
+	mexUpdateCollection = whatsmeow.RegisterMexOperation[mexCollectionVars, respUpdateCollection](
+		"WhatsAppCatalogUpdateCollection", whatsmeow.MexQueryIDs{},
+	)
+	mexDeleteCollection = whatsmeow.RegisterMexOperation[mexDeleteCollectionVars, respDeleteCollection](
+		"WhatsAppCatalogDeleteCollection", whatsmeow.MexQueryIDs{},
+	)
+)
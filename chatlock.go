@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"hash"
+
+	"go.mau.fi/util/random"
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/proto/waUserPassword"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Transformer argument keys used in chat lock secret codes.
+const (
+	PasswordTransformerArgSalt       = "salt"
+	PasswordTransformerArgIterations = "iterations"
+)
+
+// ChatLockSecretCodeIterations is the number of PBKDF2 iterations used by NewChatLockSecretCode.
+var ChatLockSecretCodeIterations uint32 = 100_000
+
+const chatLockSecretCodeSaltLength = 16
+
+func encodeUserPassword(encoding waUserPassword.UserPassword_Encoding, password string) []byte {
+	if encoding == waUserPassword.UserPassword_UTF8_BROKEN {
+		// The legacy encoding only kept the lowest byte of each character.
+		out := make([]byte, 0, len(password))
+		for _, char := range password {
+			out = append(out, byte(char))
+		}
+		return out
+	}
+	return []byte(password)
+}
+
+func getPasswordTransformerArg(password *waUserPassword.UserPassword, key string) *waUserPassword.UserPassword_TransformerArg_Value {
+	for _, arg := range password.GetTransformerArg() {
+		if arg.GetKey() == key {
+			return arg.GetValue()
+		}
+	}
+	return nil
+}
+
+func transformUserPassword(password *waUserPassword.UserPassword, plaintext string, keyLength int) ([]byte, error) {
+	encoded := encodeUserPassword(password.GetEncoding(), plaintext)
+	var hashFunc func() hash.Hash
+	switch password.GetTransformer() {
+	case waUserPassword.UserPassword_NONE:
+		return encoded, nil
+	case waUserPassword.UserPassword_PBKDF2_HMAC_SHA512:
+		hashFunc = sha512.New
+	case waUserPassword.UserPassword_PBKDF2_HMAC_SHA384:
+		hashFunc = sha512.New384
+	default:
+		return nil, fmt.Errorf("%w %s", ErrUnsupportedPasswordTransformer, password.GetTransformer())
+	}
+	salt := getPasswordTransformerArg(password, PasswordTransformerArgSalt)
+	iterations := getPasswordTransformerArg(password, PasswordTransformerArgIterations)
+	if salt == nil {
+		return nil, fmt.Errorf("%w %s", ErrMissingPasswordTransformerArg, PasswordTransformerArgSalt)
+	} else if iterations == nil || iterations.GetAsUnsignedInteger() == 0 {
+		return nil, fmt.Errorf("%w %s", ErrMissingPasswordTransformerArg, PasswordTransformerArgIterations)
+	}
+	if keyLength <= 0 {
+		keyLength = hashFunc().Size()
+	}
+	// The password was already encoded according to the encoding field, so passing it as a string doesn't change the bytes.
+	return pbkdf2.Key(hashFunc, string(encoded), salt.GetAsBlob(), int(iterations.GetAsUnsignedInteger()), keyLength)
+}
+
+// NewChatLockSecretCode derives a UserPassword from the given chat lock secret code using PBKDF2-HMAC-SHA512 with a random salt.
+//
+// The result can be sent to other devices with appstate.BuildChatLockSettings.
+func NewChatLockSecretCode(code string) (*waUserPassword.UserPassword, error) {
+	password := &waUserPassword.UserPassword{
+		Encoding:    waUserPassword.UserPassword_UTF8.Enum(),
+		Transformer: waUserPassword.UserPassword_PBKDF2_HMAC_SHA512.Enum(),
+		TransformerArg: []*waUserPassword.UserPassword_TransformerArg{{
+			Key: proto.String(PasswordTransformerArgSalt),
+			Value: &waUserPassword.UserPassword_TransformerArg_Value{
+				Value: &waUserPassword.UserPassword_TransformerArg_Value_AsBlob{
+					AsBlob: random.Bytes(chatLockSecretCodeSaltLength),
+				},
+			},
+		}, {
+			Key: proto.String(PasswordTransformerArgIterations),
+			Value: &waUserPassword.UserPassword_TransformerArg_Value{
+				Value: &waUserPassword.UserPassword_TransformerArg_Value_AsUnsignedInteger{
+					AsUnsignedInteger: ChatLockSecretCodeIterations,
+				},
+			},
+		}},
+	}
+	var err error
+	password.TransformedData, err = transformUserPassword(password, code, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive secret code: %w", err)
+	}
+	return password, nil
+}
+
+// VerifyChatLockSecretCode checks whether the given code matches the secret code in the chat lock settings
+// (e.g. from the Action field of events.ChatLockSettings).
+func VerifyChatLockSecretCode(secretCode *waUserPassword.UserPassword, code string) (bool, error) {
+	expected := secretCode.GetTransformedData()
+	if len(expected) == 0 {
+		return false, nil
+	}
+	derived, err := transformUserPassword(secretCode, code, len(expected))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(derived, expected) == 1, nil
+}
+
+// SetChatLocked locks or unlocks the given chat on all of the user's devices.
+func (cli *Client) SetChatLocked(ctx context.Context, chat types.JID, locked bool) error {
+	return cli.SendAppState(ctx, appstate.BuildLockChat(chat, locked))
+}
+
+// SetChatLockSettings changes whether locked chats are hidden and optionally sets a new secret code for them.
+//
+// If code is empty, the existing secret code is removed.
+func (cli *Client) SetChatLockSettings(ctx context.Context, hideLockedChats bool, code string) error {
+	var secretCode *waUserPassword.UserPassword
+	if code != "" {
+		var err error
+		secretCode, err = NewChatLockSecretCode(code)
+		if err != nil {
+			return err
+		}
+	}
+	return cli.SendAppState(ctx, appstate.BuildChatLockSettings(hideLockedChats, secretCode))
+}
@@ -67,6 +67,8 @@ type Client struct {
 	socket     *socket.NoiseSocket
 	socketLock sync.RWMutex
 	socketWait chan struct{}
+	// Number of consecutive failed handshakes with the cached edge routing info, protected by socketLock
+	edgeRoutingFailures int
 
 	isLoggedIn            atomic.Bool
 	expectedDisconnect    *exsync.Event
@@ -130,6 +132,23 @@ type Client struct {
 
 	privacySettingsCache atomic.Value
 
+	// If true, GetGroupInfo will return group info from Store.Groups when available instead of asking the server.
+	// The stored info is kept up to date using group change notifications, and refetched after MaxStoredGroupInfoAge.
+	// When false, group info isn't written to Store.Groups at all.
+	CacheFirstGroupInfo bool
+
+	// If true, polls and their votes won't be stored automatically, and PollResultsChanged events won't be emitted.
+	DisablePollTracking bool
+
+	// If true, the bot registry won't be refreshed from the server automatically.
+	// Bots stored from earlier calls to GetBotListV2 and the static types.BotJIDMap are still used.
+	DisableBotListRefresh bool
+	botRegistryUpdatedAt  time.Time
+	botRegistryLock       sync.Mutex
+
+	sleepMode     *sleepMode
+	sleepModeLock sync.Mutex
+
 	groupCache           map[types.JID]*groupMetaCache
 	groupCacheLock       sync.Mutex
 	userDevicesCache     map[types.JID]deviceCache
@@ -177,6 +196,10 @@ type Client struct {
 
 	phoneLinkingCache *phoneLinkingCache
 
+	statusTracker      *statusTracker
+	presenceTracker    *presenceTracker
+	botResponseTracker *botResponseTracker
+
 	uniqueID  string
 	idCounter atomic.Uint64
 
@@ -266,6 +289,10 @@ func NewClient(deviceStore *store.Device, log waLog.Logger) *Client {
 
 		pendingPhoneRerequests: make(map[types.MessageID]context.CancelFunc),
 
+		statusTracker:      newStatusTracker(),
+		presenceTracker:    newPresenceTracker(),
+		botResponseTracker: newBotResponseTracker(),
+
 		EnableAutoReconnect: true,
 		AutoTrustIdentity:   true,
 
@@ -527,14 +554,25 @@ func (cli *Client) unlockedConnect(ctx context.Context) error {
 		//fs.HTTPHeaders.Set("Sec-Fetch-Mode", "websocket")
 		//fs.HTTPHeaders.Set("Sec-Fetch-Site", "cross-site")
 	}
+	routingInfo := cli.Store.EdgeRoutingInfo
+	fs.RoutingHeader = socket.MakeEdgeRoutingHeader(routingInfo)
 	if err := fs.Connect(ctx); err != nil {
 		fs.Close(0)
 		return err
 	} else if err = cli.doHandshake(ctx, fs, *keys.NewKeyPair()); err != nil {
 		fs.Close(0)
+		if routingInfo != nil && ctx.Err() == nil {
+			cli.edgeRoutingFailures++
+			if cli.edgeRoutingFailures >= maxEdgeRoutingFailures {
+				// The preferred edge may be gone, so stop trying it after several failed handshakes in a row
+				cli.clearEdgeRoutingInfo(ctx)
+			}
+		}
 		return fmt.Errorf("noise handshake failed: %w", err)
 	}
+	cli.edgeRoutingFailures = 0
 	go cli.keepAliveLoop(ctx, fs.Context())
+	go cli.botRegistryRefreshLoop(ctx, fs.Context())
 	go cli.handlerQueueLoop(ctx, fs.Context())
 	return nil
 }
@@ -551,6 +589,7 @@ func (cli *Client) onDisconnect(ctx context.Context, ns *socket.NoiseSocket, rem
 	if cli.socket == ns {
 		cli.socket = nil
 		cli.clearResponseWaiters(xmlStreamEndNode)
+		cli.presenceTracker.markDisconnected()
 		if !cli.isExpectedDisconnect() && (cli.forceAutoReconnect.Swap(false) || remote) {
 			cli.Log.Debugf("Emitting Disconnected event")
 			go cli.dispatchEvent(&events.Disconnected{})
@@ -652,6 +691,7 @@ func (cli *Client) ResetConnection() {
 	if cli.socket != nil {
 		cli.socket.Stop(true, true)
 		cli.clearResponseWaiters(xmlStreamEndNode)
+		cli.presenceTracker.markDisconnected()
 	}
 	cli.socketLock.Unlock()
 }
@@ -662,6 +702,7 @@ func (cli *Client) unlockedDisconnect() {
 		cli.socket.Stop(true, false)
 		cli.socket = nil
 		cli.clearResponseWaiters(xmlStreamEndNode)
+		cli.presenceTracker.markDisconnected()
 	}
 }
 
@@ -837,6 +878,7 @@ Loop:
 	for {
 		select {
 		case node := <-cli.handlerQueue:
+			cli.markSleepModeActivity()
 			doneChan := make(chan struct{}, 1)
 			start := time.Now()
 			go func() {
@@ -0,0 +1,295 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+)
+
+type mexGroupIDInput struct {
+	GroupID types.JID `json:"group_id"`
+}
+
+type mexGroupQueryVars struct {
+	Input mexGroupIDInput `json:"input"`
+}
+
+type mexSubGroupSuggestionNode struct {
+	ID      types.JID `json:"id"`
+	Subject *struct {
+		Value string `json:"value"`
+	} `json:"subject"`
+	Description *struct {
+		Value string `json:"value"`
+	} `json:"description"`
+	Creator struct {
+		ID types.JID `json:"id"`
+	} `json:"creator"`
+	CreationTime           int64 `json:"creation_time,string"`
+	TotalParticipantsCount int   `json:"total_participants_count"`
+	IsExistingGroup        bool  `json:"is_existing_group"`
+	HiddenGroup            bool  `json:"hidden_group"`
+}
+
+type respQuerySuggestedGroups struct {
+	Group *struct {
+		ID                  types.JID `json:"id"`
+		SubGroupSuggestions *struct {
+			Edges []struct {
+				Node mexSubGroupSuggestionNode `json:"node"`
+			} `json:"edges"`
+		} `json:"sub_group_suggestions"`
+	} `json:"xwa2_group_query_by_id"`
+}
+
+type mexUpdateRolesInput struct {
+	GroupID     types.JID               `json:"group_id"`
+	RoleUpdates []types.GroupRoleUpdate `json:"role_updates"`
+}
+
+type mexUpdateRolesVars struct {
+	Input mexUpdateRolesInput `json:"input"`
+}
+
+type respUpdateCommunityRoles struct {
+	Result *struct {
+		GroupID types.JID `json:"group_id"`
+	} `json:"xwa2_group_update_users_role"`
+}
+
+type mexAllowNonAdminGroupCreationInput struct {
+	GroupID                       types.JID `json:"group_id"`
+	AllowNonAdminSubGroupCreation bool      `json:"allow_non_admin_sub_group_creation"`
+}
+
+type mexAllowNonAdminGroupCreationVars struct {
+	Input mexAllowNonAdminGroupCreationInput `json:"input"`
+}
+
+type respUpdateGroupProperty struct {
+	Result *struct {
+		ID    types.JID `json:"id"`
+		State string    `json:"state"`
+	} `json:"xwa2_group_update_property"`
+}
+
+var (
+	mexQuerySuggestedGroups = RegisterMexOperation[mexGroupQueryVars, respQuerySuggestedGroups](
+		"QuerySuggestedGroups", MexQueryIDs{},
+	)
+	mexUpdateCommunityOwner = RegisterMexOperation[mexUpdateRolesVars, respUpdateCommunityRoles](
+		"UpdateCommunityOwner", MexQueryIDs{},
+	)
+	mexAllowNonAdminGroupCreation = RegisterMexOperation[mexAllowNonAdminGroupCreationVars, respUpdateGroupProperty](
+		"AllowNonAdminGroupCreation", MexQueryIDs{},
+	)
+)
+
+// DeactivateCommunity deactivates (deletes) a community. All groups in the community are unlinked,
+// and the announcement group is deleted. Only the community owner can do this.
+//
+// The change is sent to other participants as an events.GroupInfo with Unlink.UnlinkReason set to types.GroupUnlinkReasonDelete.
+func (cli *Client) DeactivateCommunity(ctx context.Context, community types.JID) error {
+	_, err := cli.sendGroupIQ(ctx, iqSet, community, waBinary.Node{Tag: "delete_parent"})
+	return err
+}
+
+// GetCommunityAnnouncementGroup finds the announcement group (i.e. the default subgroup) of the given community.
+func (cli *Client) GetCommunityAnnouncementGroup(ctx context.Context, community types.JID) (*types.GroupLinkTarget, error) {
+	subGroups, err := cli.GetSubGroups(ctx, community)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range subGroups {
+		if group.IsDefaultSubGroup {
+			return group, nil
+		}
+	}
+	return nil, ErrNoAnnouncementGroup
+}
+
+// CommunityAnnouncementGroupSettings contains the settings that can be changed in a community's announcement group
+// using SetCommunityAnnouncementGroupSettings. Nil fields are left unchanged.
+type CommunityAnnouncementGroupSettings struct {
+	// Whether only admins can send messages in the announcement group.
+	OnlyAdminsCanSend *bool
+	// Whether only admins can edit the announcement group's info.
+	OnlyAdminsCanEdit *bool
+	// The disappearing message timer. Zero disables disappearing messages.
+	DisappearingTimer *time.Duration
+}
+
+// SetCommunityAnnouncementGroupSettings changes the settings of the given community's announcement group.
+func (cli *Client) SetCommunityAnnouncementGroupSettings(ctx context.Context, community types.JID, settings CommunityAnnouncementGroupSettings) error {
+	group, err := cli.GetCommunityAnnouncementGroup(ctx, community)
+	if err != nil {
+		return err
+	}
+	if settings.OnlyAdminsCanSend != nil {
+		err = cli.SetGroupAnnounce(ctx, group.JID, *settings.OnlyAdminsCanSend)
+		if err != nil {
+			return fmt.Errorf("failed to change announcement mode: %w", err)
+		}
+	}
+	if settings.OnlyAdminsCanEdit != nil {
+		err = cli.SetGroupLocked(ctx, group.JID, *settings.OnlyAdminsCanEdit)
+		if err != nil {
+			return fmt.Errorf("failed to change locked mode: %w", err)
+		}
+	}
+	if settings.DisappearingTimer != nil {
+		err = cli.SetDisappearingTimer(ctx, group.JID, *settings.DisappearingTimer, time.Time{})
+		if err != nil {
+			return fmt.Errorf("failed to change disappearing timer: %w", err)
+		}
+	}
+	return nil
+}
+
+// SetCommunityAllowNonAdminSubGroupCreation changes whether non-admin members of the community can create new groups in it.
+//
+// If non-admins aren't allowed to create groups, they can still suggest groups,
+// which can be listed with GetSubGroupSuggestions and approved with UpdateSubGroupSuggestions.
+func (cli *Client) SetCommunityAllowNonAdminSubGroupCreation(ctx context.Context, community types.JID, allow bool) error {
+	_, err := SendMexOperation(ctx, cli, mexAllowNonAdminGroupCreation, mexAllowNonAdminGroupCreationVars{
+		Input: mexAllowNonAdminGroupCreationInput{
+			GroupID:                       community,
+			AllowNonAdminSubGroupCreation: allow,
+		},
+	})
+	return err
+}
+
+// GetSubGroupSuggestions gets the list of groups that community members have suggested to be added to the community.
+func (cli *Client) GetSubGroupSuggestions(ctx context.Context, community types.JID) ([]*types.SubGroupSuggestion, error) {
+	resp, err := SendMexOperation(ctx, cli, mexQuerySuggestedGroups, mexGroupQueryVars{
+		Input: mexGroupIDInput{GroupID: community},
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Group == nil {
+		return nil, ErrGroupNotFound
+	} else if resp.Group.SubGroupSuggestions == nil {
+		return nil, nil
+	}
+	suggestions := make([]*types.SubGroupSuggestion, len(resp.Group.SubGroupSuggestions.Edges))
+	for i, edge := range resp.Group.SubGroupSuggestions.Edges {
+		node := edge.Node
+		suggestions[i] = &types.SubGroupSuggestion{
+			JID:              node.ID,
+			Creator:          node.Creator.ID,
+			ParticipantCount: node.TotalParticipantsCount,
+			IsExistingGroup:  node.IsExistingGroup,
+			IsHidden:         node.HiddenGroup,
+		}
+		if node.CreationTime > 0 {
+			suggestions[i].CreatedAt = time.Unix(node.CreationTime, 0)
+		}
+		if node.Subject != nil {
+			suggestions[i].Name = node.Subject.Value
+		}
+		if node.Description != nil {
+			suggestions[i].Topic = node.Description.Value
+		}
+	}
+	return suggestions, nil
+}
+
+type SubGroupSuggestionChange string
+
+const (
+	SubGroupSuggestionChangeApprove SubGroupSuggestionChange = "approve"
+	SubGroupSuggestionChangeReject  SubGroupSuggestionChange = "reject"
+)
+
+// UpdateSubGroupSuggestions can be used to approve or reject groups that members have suggested to be added to the community.
+//
+// Approved groups are linked to the community, which is sent as an events.GroupInfo with the Link field set.
+// The returned list contains the groups that were successfully updated.
+func (cli *Client) UpdateSubGroupSuggestions(ctx context.Context, community types.JID, groups []types.JID, action SubGroupSuggestionChange) ([]types.GroupLinkTarget, error) {
+	content := make([]waBinary.Node, len(groups))
+	for i, group := range groups {
+		content[i] = waBinary.Node{
+			Tag:   "group",
+			Attrs: waBinary.Attrs{"jid": group},
+		}
+	}
+	resp, err := cli.sendGroupIQ(ctx, iqSet, community, waBinary.Node{
+		Tag: "sub_group_suggestions_action",
+		Content: []waBinary.Node{{
+			Tag:     string(action),
+			Content: content,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	request, ok := resp.GetOptionalChildByTag("sub_group_suggestions_action", string(action))
+	if !ok {
+		return nil, &ElementMissingError{Tag: string(action), In: "response to sub group suggestions update"}
+	}
+	groupNodes := request.GetChildrenByTag("group")
+	results := make([]types.GroupLinkTarget, 0, len(groupNodes))
+	for _, child := range groupNodes {
+		result, err := parseGroupLinkTargetNode(&child)
+		if err != nil {
+			return results, fmt.Errorf("failed to parse group in sub group suggestions update response: %w", err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// GetCommunityAdmins gets the list of community-wide admins in the given community, including the owner.
+func (cli *Client) GetCommunityAdmins(ctx context.Context, community types.JID) ([]types.GroupParticipant, error) {
+	info, err := cli.GetGroupInfo(ctx, community)
+	if err != nil {
+		return nil, err
+	} else if !info.IsParent {
+		return nil, ErrNotCommunity
+	}
+	admins := make([]types.GroupParticipant, 0)
+	for _, participant := range info.Participants {
+		if participant.IsAdmin || participant.IsSuperAdmin {
+			admins = append(admins, participant)
+		}
+	}
+	return admins, nil
+}
+
+// PromoteCommunityAdmins promotes the given community members to community-wide admins.
+//
+// Community admins can manage all groups in the community. This is the same as promoting
+// the users in the parent group with UpdateGroupParticipants.
+func (cli *Client) PromoteCommunityAdmins(ctx context.Context, community types.JID, users []types.JID) error {
+	_, err := cli.UpdateGroupParticipants(ctx, community, users, ParticipantChangePromote)
+	return err
+}
+
+// DemoteCommunityAdmins demotes the given community-wide admins back to regular members.
+func (cli *Client) DemoteCommunityAdmins(ctx context.Context, community types.JID, users []types.JID) error {
+	_, err := cli.UpdateGroupParticipants(ctx, community, users, ParticipantChangeDemote)
+	return err
+}
+
+// SetCommunityOwner transfers the ownership of the community to the given user.
+//
+// The change is sent to other participants as an events.CommunityParticipantRolesChange.
+func (cli *Client) SetCommunityOwner(ctx context.Context, community, newOwner types.JID) error {
+	_, err := SendMexOperation(ctx, cli, mexUpdateCommunityOwner, mexUpdateRolesVars{
+		Input: mexUpdateRolesInput{
+			GroupID:     community,
+			RoleUpdates: []types.GroupRoleUpdate{{User: newOwner, NewRole: types.GroupParticipantRoleSuperAdmin}},
+		},
+	})
+	return err
+}
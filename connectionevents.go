@@ -7,6 +7,7 @@
 package whatsmeow
 
 import (
+	"bytes"
 	"context"
 	"time"
 
@@ -88,6 +89,13 @@ func (cli *Client) handleIB(ctx context.Context, node *waBinary.Node) {
 			cli.dispatchEvent(&events.OfflineSyncCompleted{
 				Count: ag.Int("count"),
 			})
+			cli.handleSleepModeOfflineSync()
+		case "edge_routing":
+			routingInfo, ok := child.GetOptionalChildByTag("routing_info")
+			if ok {
+				data, _ := routingInfo.Content.([]byte)
+				cli.updateEdgeRoutingInfo(ctx, data)
+			}
 		case "dirty":
 			//ts := ag.UnixTime("timestamp")
 			//typ := ag.String("type") // account_sync
@@ -99,6 +107,40 @@ func (cli *Client) handleIB(ctx context.Context, node *waBinary.Node) {
 	}
 }
 
+func (cli *Client) updateEdgeRoutingInfo(ctx context.Context, routingInfo []byte) {
+	if len(routingInfo) == 0 || bytes.Equal(cli.Store.EdgeRoutingInfo, routingInfo) {
+		return
+	}
+	cli.Store.EdgeRoutingInfo = routingInfo
+	if cli.Store.ID == nil {
+		// Routing info received before pairing will be saved along with the rest of the device
+		return
+	}
+	err := cli.Store.Save(ctx)
+	if err != nil {
+		cli.Log.Warnf("Failed to save device after updating edge routing info: %v", err)
+	} else {
+		cli.Log.Debugf("Updated edge routing info (%d bytes)", len(routingInfo))
+	}
+}
+
+// maxEdgeRoutingFailures is the number of consecutive failed handshakes after which the cached edge routing info is
+// cleared. A single failure is usually just a network issue, so the preferred edge isn't thrown away immediately.
+const maxEdgeRoutingFailures = 3
+
+func (cli *Client) clearEdgeRoutingInfo(ctx context.Context) {
+	cli.Log.Debugf("Clearing edge routing info")
+	cli.Store.EdgeRoutingInfo = nil
+	cli.edgeRoutingFailures = 0
+	if cli.Store.ID == nil {
+		return
+	}
+	err := cli.Store.Save(ctx)
+	if err != nil {
+		cli.Log.Warnf("Failed to save device after clearing edge routing info: %v", err)
+	}
+}
+
 func (cli *Client) handleConnectFailure(ctx context.Context, node *waBinary.Node) {
 	ag := node.AttrGetter()
 	reason := events.ConnectFailureReason(ag.Int("reason"))
@@ -178,6 +220,10 @@ func (cli *Client) handleConnectSuccess(ctx context.Context, node *waBinary.Node
 			cli.Log.Infof("Updated LID to %s", cli.Store.LID)
 		}
 	}
+	if routingInfo, ok := node.GetOptionalChildByTag("edge_routing", "routing_info"); ok {
+		data, _ := routingInfo.Content.([]byte)
+		cli.updateEdgeRoutingInfo(ctx, data)
+	}
 	// Some users are missing their own LID-PN mapping even though it's already in the device table,
 	// so do this unconditionally for a few months to ensure everyone gets the row.
 	cli.StoreLIDPNMapping(ctx, cli.Store.GetLID(), cli.Store.GetJID())
@@ -200,6 +246,8 @@ func (cli *Client) handleConnectSuccess(ctx context.Context, node *waBinary.Node
 		}
 		cli.dispatchEvent(&events.Connected{})
 		cli.closeSocketWaitChan()
+		cli.resubscribePresence(ctx)
+		cli.refreshBotRegistryIfStale(ctx)
 	}()
 }
 
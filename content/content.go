@@ -0,0 +1,215 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package content normalizes WhatsApp message protobufs into a single typed model.
+//
+// Instead of checking dozens of fields in waE2E.Message, callers can use Parse or FromEvent and then switch on Content.Kind:
+//
+//	c := content.FromEvent(evt)
+//	switch c.Kind {
+//	case content.KindText:
+//		fmt.Println(c.Body)
+//	case content.KindImage:
+//		data, err := cli.Download(ctx, c.Media.Downloadable)
+//	}
+package content
+
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/formatting"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/proto/waMediaTransport"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Kind is the type of content in a message.
+type Kind string
+
+// The known content kinds.
+const (
+	KindUnknown      Kind = ""
+	KindText         Kind = "text"
+	KindImage        Kind = "image"
+	KindVideo        Kind = "video"
+	KindAudio        Kind = "audio"
+	KindDocument     Kind = "document"
+	KindSticker      Kind = "sticker"
+	KindLocation     Kind = "location"
+	KindLiveLocation Kind = "live_location"
+	KindContact      Kind = "contact"
+	KindPoll         Kind = "poll"
+	KindPollVote     Kind = "poll_vote"
+	KindReaction     Kind = "reaction"
+	KindEdit         Kind = "edit"
+	KindRevoke       Kind = "revoke"
+)
+
+// IsMedia returns true if the kind has a downloadable attachment in Content.Media.
+func (k Kind) IsMedia() bool {
+	switch k {
+	case KindImage, KindVideo, KindAudio, KindDocument, KindSticker:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wrappers contains the wrapper messages that the content was unwrapped from.
+type Wrappers struct {
+	DeviceSent          bool
+	BotInvoke           bool
+	Ephemeral           bool
+	ViewOnce            bool
+	ViewOnceV2          bool
+	ViewOnceV2Extension bool
+	LottieSticker       bool
+	DocumentWithCaption bool
+	Edit                bool
+}
+
+// MessageRef points at another message, e.g. the target of a reply or reaction.
+type MessageRef struct {
+	ID   types.MessageID
+	Chat types.JID
+	// The sender of the referenced message. This may be empty if the protobuf didn't specify it
+	// and the content wasn't parsed with FromEvent (which fills it based on the message info).
+	// It's also empty for references to own messages in incoming DMs, in which case FromMe is true.
+	Sender types.JID
+	FromMe bool
+}
+
+// Reply is the message that the content is replying to.
+type Reply struct {
+	MessageRef
+	// The quoted content of the replied-to message, as included by the sender.
+	Quoted *Content
+}
+
+// Downloadable is the subset of media message methods needed by whatsmeow.Client.Download.
+//
+// All the waE2E media messages implement it, so Media.Downloadable can be passed to Download directly.
+type Downloadable interface {
+	GetDirectPath() string
+	GetMediaKey() []byte
+	GetFileSHA256() []byte
+	GetFileEncSHA256() []byte
+}
+
+// Media describes an attachment.
+type Media struct {
+	MimeType   string
+	FileName   string
+	FileLength uint64
+	Width      uint32
+	Height     uint32
+	Duration   time.Duration
+	Thumbnail  []byte
+
+	// True for voice messages.
+	PTT bool
+	// True for GIFs (videos with gif playback) and animated stickers.
+	Animated bool
+	// True for round video notes.
+	VideoNote bool
+
+	// The raw media message, which can be passed to whatsmeow.Client.Download.
+	// This is nil for Messenger/Instagram (FBMessage) media, which uses FBTransport instead.
+	Downloadable Downloadable
+	// The decoded media transport of Messenger/Instagram (FBMessage) media,
+	// which can be passed to whatsmeow.Client.DownloadFB along with the media type matching the Kind.
+	FBTransport *waMediaTransport.WAMediaTransport_Integral
+}
+
+// Location is a static or live location.
+type Location struct {
+	Latitude       float64
+	Longitude      float64
+	AccuracyMeters uint32
+	Name           string
+	Address        string
+	URL            string
+
+	// Only for live locations
+	SequenceNumber int64
+	SpeedMPS       float32
+}
+
+// Contact is a shared contact card.
+type Contact struct {
+	DisplayName string
+	VCard       string
+}
+
+// Poll is a poll creation message.
+type Poll struct {
+	Name            string
+	Options         []string
+	SelectableCount uint32
+	// The secret that is needed to decrypt votes to this poll.
+	EncKey []byte
+}
+
+// PollVote is an encrypted vote to a poll. Use whatsmeow.Client.DecryptPollVote to read the selected options.
+type PollVote struct {
+	Poll      MessageRef
+	Encrypted *waE2E.PollEncValue
+}
+
+// Reaction is a reaction to another message.
+type Reaction struct {
+	Target MessageRef
+	Emoji  string
+	// True if the reaction removes the sender's previous reaction.
+	Removed bool
+}
+
+// Edit is an edit to a previously sent message.
+type Edit struct {
+	Target     MessageRef
+	NewContent *Content
+}
+
+// Revoke is a deletion of a previously sent message.
+type Revoke struct {
+	Target MessageRef
+}
+
+// Content is the normalized content of a message.
+//
+// Only the field that matches the Kind is filled, except for Body, Mentions, ReplyTo and the forwarding info,
+// which apply to all kinds that can have text or a context info.
+type Content struct {
+	Kind Kind
+	// The text of the message, or the caption for media.
+	Body string
+	// Users mentioned in Body. Use Formatted to get the positions of the mentions along with other formatting.
+	Mentions []types.JID
+	ReplyTo  *Reply
+
+	Forwarded       bool
+	ForwardingScore uint32
+	// The disappearing message timer that the message was sent with.
+	Expiration time.Duration
+
+	Media    *Media
+	Location *Location
+	Contacts []Contact
+	Poll     *Poll
+	PollVote *PollVote
+	Reaction *Reaction
+	Edit     *Edit
+	Revoke   *Revoke
+
+	Wrappers Wrappers
+	// The unwrapped message that the content was parsed from. This is nil for FBMessages.
+	Raw *waE2E.Message
+}
+
+// Formatted parses the formatting and mentions in Body.
+func (c *Content) Formatted() []*formatting.Node {
+	return formatting.Parse(c.Body, c.Mentions...)
+}
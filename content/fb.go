@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package content
+
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waConsumerApplication"
+	"go.mau.fi/whatsmeow/proto/waMediaTransport"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// FromFBEvent normalizes the consumer application message in the given Messenger/Instagram event.
+//
+// Media in FB messages is wrapped in encoded sub-protocol payloads, so Media.Downloadable is always nil.
+// The decoded transport is in Media.FBTransport, which can be passed to whatsmeow.Client.DownloadFB.
+// Other message applications (e.g. armadillo) result in KindUnknown.
+func FromFBEvent(evt *events.FBMessage) *Content {
+	c := ParseConsumerApplication(evt.GetConsumerApplication())
+	c.resolveRefs(&evt.Info)
+	return c
+}
+
+// ParseConsumerApplication normalizes the given FB consumer application message.
+func ParseConsumerApplication(msg *waConsumerApplication.ConsumerApplication) *Content {
+	c := &Content{}
+	if revoke := msg.GetPayload().GetApplicationData().GetRevoke(); revoke != nil {
+		c.Kind = KindRevoke
+		c.Revoke = &Revoke{Target: refFromKey(revoke.GetKey())}
+		return c
+	}
+	content := msg.GetPayload().GetContent()
+	switch {
+	case content.GetMessageText() != nil:
+		c.Kind = KindText
+		c.applyMessageText(content.GetMessageText())
+	case content.GetExtendedTextMessage() != nil:
+		c.Kind = KindText
+		c.applyMessageText(content.GetExtendedTextMessage().GetText())
+	case content.GetImageMessage() != nil:
+		c.Kind = KindImage
+		c.applyMessageText(content.GetImageMessage().GetCaption())
+		c.Media = &Media{}
+		if dec, err := content.GetImageMessage().Decode(); err == nil {
+			c.Media = fbMedia(dec.GetIntegral().GetTransport())
+			c.Media.Width = dec.GetAncillary().GetWidth()
+			c.Media.Height = dec.GetAncillary().GetHeight()
+		}
+	case content.GetVideoMessage() != nil:
+		c.Kind = KindVideo
+		c.applyMessageText(content.GetVideoMessage().GetCaption())
+		c.Media = &Media{}
+		if dec, err := content.GetVideoMessage().Decode(); err == nil {
+			c.Media = fbMedia(dec.GetIntegral().GetTransport())
+			c.Media.Width = dec.GetAncillary().GetWidth()
+			c.Media.Height = dec.GetAncillary().GetHeight()
+			c.Media.Duration = time.Duration(dec.GetAncillary().GetSeconds()) * time.Second
+			c.Media.Animated = dec.GetAncillary().GetGifPlayback()
+		}
+	case content.GetAudioMessage() != nil:
+		c.Kind = KindAudio
+		c.Media = &Media{}
+		if dec, err := content.GetAudioMessage().Decode(); err == nil {
+			c.Media = fbMedia(dec.GetIntegral().GetTransport())
+			c.Media.Duration = time.Duration(dec.GetAncillary().GetSeconds()) * time.Second
+		}
+		c.Media.PTT = content.GetAudioMessage().GetPTT()
+	case content.GetDocumentMessage() != nil:
+		c.Kind = KindDocument
+		c.Media = &Media{}
+		if dec, err := content.GetDocumentMessage().Decode(); err == nil {
+			c.Media = fbMedia(dec.GetIntegral().GetTransport())
+		}
+		c.Media.FileName = content.GetDocumentMessage().GetFileName()
+	case content.GetStickerMessage() != nil:
+		c.Kind = KindSticker
+		c.Media = &Media{}
+		if dec, err := content.GetStickerMessage().Decode(); err == nil {
+			c.Media = fbMedia(dec.GetIntegral().GetTransport())
+			c.Media.Width = dec.GetAncillary().GetWidth()
+			c.Media.Height = dec.GetAncillary().GetHeight()
+			c.Media.Animated = dec.GetIntegral().GetIsAnimated()
+		}
+	case content.GetLocationMessage() != nil:
+		loc := content.GetLocationMessage()
+		c.Kind = KindLocation
+		c.Location = &Location{
+			Latitude:  loc.GetLocation().GetDegreesLatitude(),
+			Longitude: loc.GetLocation().GetDegreesLongitude(),
+			Name:      loc.GetLocation().GetName(),
+			Address:   loc.GetAddress(),
+		}
+	case content.GetReactionMessage() != nil:
+		c.Kind = KindReaction
+		c.Reaction = &Reaction{
+			Target:  refFromKey(content.GetReactionMessage().GetKey()),
+			Emoji:   content.GetReactionMessage().GetText(),
+			Removed: content.GetReactionMessage().GetText() == "",
+		}
+	case content.GetEditMessage() != nil:
+		edit := content.GetEditMessage()
+		c.Kind = KindEdit
+		newContent := &Content{Kind: KindText}
+		newContent.applyMessageText(edit.GetMessage())
+		c.Edit = &Edit{
+			Target:     refFromKey(edit.GetKey()),
+			NewContent: newContent,
+		}
+		c.Body = newContent.Body
+		c.Mentions = newContent.Mentions
+	}
+	return c
+}
+
+func fbMedia(transport *waMediaTransport.WAMediaTransport) *Media {
+	return &Media{
+		MimeType:    transport.GetAncillary().GetMimetype(),
+		FileLength:  transport.GetAncillary().GetFileLength(),
+		Thumbnail:   transport.GetAncillary().GetThumbnail().GetJPEGThumbnail(),
+		FBTransport: transport.GetIntegral(),
+	}
+}
+
+func (c *Content) applyMessageText(text *waCommon.MessageText) {
+	c.Body = text.GetText()
+	for _, mention := range text.GetMentionedJID() {
+		if jid := parseJIDOrEmpty(mention); !jid.IsEmpty() {
+			c.Mentions = append(c.Mentions, jid)
+		}
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package content
+
+import (
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Unwrap removes the wrapper messages (device sent, ephemeral, view once, etc.) around the actual content.
+//
+// This does the same as events.Message.UnwrapRaw, but works on any message, e.g. quoted messages.
+func Unwrap(msg *waE2E.Message) (*waE2E.Message, Wrappers) {
+	var w Wrappers
+	if inner := msg.GetDeviceSentMessage().GetMessage(); inner != nil {
+		msg = inner
+		w.DeviceSent = true
+	}
+	if inner := msg.GetBotInvokeMessage().GetMessage(); inner != nil {
+		msg = inner
+		w.BotInvoke = true
+	}
+	if inner := msg.GetEphemeralMessage().GetMessage(); inner != nil {
+		msg = inner
+		w.Ephemeral = true
+	}
+	if inner := msg.GetViewOnceMessage().GetMessage(); inner != nil {
+		msg = inner
+		w.ViewOnce = true
+	}
+	if inner := msg.GetViewOnceMessageV2().GetMessage(); inner != nil {
+		msg = inner
+		w.ViewOnce = true
+		w.ViewOnceV2 = true
+	}
+	if inner := msg.GetViewOnceMessageV2Extension().GetMessage(); inner != nil {
+		msg = inner
+		w.ViewOnce = true
+		w.ViewOnceV2 = true
+		w.ViewOnceV2Extension = true
+	}
+	if inner := msg.GetLottieStickerMessage().GetMessage(); inner != nil {
+		msg = inner
+		w.LottieSticker = true
+	}
+	if inner := msg.GetDocumentWithCaptionMessage().GetMessage(); inner != nil {
+		msg = inner
+		w.DocumentWithCaption = true
+	}
+	if inner := msg.GetEditedMessage().GetMessage(); inner != nil {
+		msg = inner
+		w.Edit = true
+	}
+	return msg, w
+}
+
+// Parse normalizes the given message. Wrapper messages are removed automatically.
+//
+// Message references (reply targets, reaction targets, etc.) are filled based on the protobuf only.
+// Use FromEvent to also resolve them relative to the chat and sender of the message.
+func Parse(msg *waE2E.Message) *Content {
+	if msg == nil {
+		return &Content{}
+	}
+	inner, wrappers := Unwrap(msg)
+	c := parseUnwrapped(inner)
+	c.Wrappers = wrappers
+	return c
+}
+
+// FromEvent normalizes the message in the given event.
+//
+// Unlike Parse, this also fills the chat and sender of message references when the protobuf omits them,
+// e.g. reactions to messages in direct chats.
+func FromEvent(evt *events.Message) *Content {
+	c := Parse(evt.RawMessage)
+	if evt.RawMessage == nil {
+		c = Parse(evt.Message)
+	}
+	c.resolveRefs(&evt.Info)
+	return c
+}
+
+type contextInfoable interface {
+	GetContextInfo() *waE2E.ContextInfo
+}
+
+func parseUnwrapped(msg *waE2E.Message) *Content {
+	c := &Content{Raw: msg}
+	var ctxInfoSource contextInfoable
+	switch {
+	case msg.Conversation != nil:
+		c.Kind = KindText
+		c.Body = msg.GetConversation()
+	case msg.ExtendedTextMessage != nil:
+		c.Kind = KindText
+		c.Body = msg.GetExtendedTextMessage().GetText()
+		ctxInfoSource = msg.GetExtendedTextMessage()
+	case msg.ImageMessage != nil:
+		img := msg.GetImageMessage()
+		c.Kind = KindImage
+		c.Body = img.GetCaption()
+		c.Media = &Media{
+			MimeType:     img.GetMimetype(),
+			FileLength:   img.GetFileLength(),
+			Width:        img.GetWidth(),
+			Height:       img.GetHeight(),
+			Thumbnail:    img.GetJPEGThumbnail(),
+			Downloadable: img,
+		}
+		ctxInfoSource = img
+	case msg.VideoMessage != nil, msg.PtvMessage != nil:
+		video := msg.GetVideoMessage()
+		if video == nil {
+			video = msg.GetPtvMessage()
+		}
+		c.Kind = KindVideo
+		c.Body = video.GetCaption()
+		c.Media = &Media{
+			MimeType:     video.GetMimetype(),
+			FileLength:   video.GetFileLength(),
+			Width:        video.GetWidth(),
+			Height:       video.GetHeight(),
+			Duration:     time.Duration(video.GetSeconds()) * time.Second,
+			Thumbnail:    video.GetJPEGThumbnail(),
+			Animated:     video.GetGifPlayback(),
+			VideoNote:    msg.PtvMessage != nil,
+			Downloadable: video,
+		}
+		ctxInfoSource = video
+	case msg.AudioMessage != nil:
+		audio := msg.GetAudioMessage()
+		c.Kind = KindAudio
+		c.Media = &Media{
+			MimeType:     audio.GetMimetype(),
+			FileLength:   audio.GetFileLength(),
+			Duration:     time.Duration(audio.GetSeconds()) * time.Second,
+			PTT:          audio.GetPTT(),
+			Downloadable: audio,
+		}
+		ctxInfoSource = audio
+	case msg.DocumentMessage != nil:
+		doc := msg.GetDocumentMessage()
+		c.Kind = KindDocument
+		c.Body = doc.GetCaption()
+		c.Media = &Media{
+			MimeType:     doc.GetMimetype(),
+			FileName:     doc.GetFileName(),
+			FileLength:   doc.GetFileLength(),
+			Thumbnail:    doc.GetJPEGThumbnail(),
+			Downloadable: doc,
+		}
+		ctxInfoSource = doc
+	case msg.StickerMessage != nil:
+		sticker := msg.GetStickerMessage()
+		c.Kind = KindSticker
+		c.Media = &Media{
+			MimeType:     sticker.GetMimetype(),
+			FileLength:   sticker.GetFileLength(),
+			Width:        sticker.GetWidth(),
+			Height:       sticker.GetHeight(),
+			Thumbnail:    sticker.GetPngThumbnail(),
+			Animated:     sticker.GetIsAnimated(),
+			Downloadable: sticker,
+		}
+		ctxInfoSource = sticker
+	case msg.LocationMessage != nil:
+		loc := msg.GetLocationMessage()
+		c.Kind = KindLocation
+		c.Body = loc.GetComment()
+		c.Location = &Location{
+			Latitude:       loc.GetDegreesLatitude(),
+			Longitude:      loc.GetDegreesLongitude(),
+			AccuracyMeters: loc.GetAccuracyInMeters(),
+			Name:           loc.GetName(),
+			Address:        loc.GetAddress(),
+			URL:            loc.GetURL(),
+		}
+		ctxInfoSource = loc
+	case msg.LiveLocationMessage != nil:
+		loc := msg.GetLiveLocationMessage()
+		c.Kind = KindLiveLocation
+		c.Body = loc.GetCaption()
+		c.Location = &Location{
+			Latitude:       loc.GetDegreesLatitude(),
+			Longitude:      loc.GetDegreesLongitude(),
+			AccuracyMeters: loc.GetAccuracyInMeters(),
+			SequenceNumber: loc.GetSequenceNumber(),
+			SpeedMPS:       loc.GetSpeedInMps(),
+		}
+		ctxInfoSource = loc
+	case msg.ContactMessage != nil:
+		c.Kind = KindContact
+		c.Contacts = []Contact{{
+			DisplayName: msg.GetContactMessage().GetDisplayName(),
+			VCard:       msg.GetContactMessage().GetVcard(),
+		}}
+		ctxInfoSource = msg.GetContactMessage()
+	case msg.ContactsArrayMessage != nil:
+		c.Kind = KindContact
+		c.Body = msg.GetContactsArrayMessage().GetDisplayName()
+		for _, contact := range msg.GetContactsArrayMessage().GetContacts() {
+			c.Contacts = append(c.Contacts, Contact{
+				DisplayName: contact.GetDisplayName(),
+				VCard:       contact.GetVcard(),
+			})
+		}
+		ctxInfoSource = msg.GetContactsArrayMessage()
+	case msg.PollCreationMessage != nil, msg.PollCreationMessageV2 != nil, msg.PollCreationMessageV3 != nil:
+		poll := msg.GetPollCreationMessage()
+		if poll == nil {
+			poll = msg.GetPollCreationMessageV2()
+		}
+		if poll == nil {
+			poll = msg.GetPollCreationMessageV3()
+		}
+		c.Kind = KindPoll
+		c.Body = poll.GetName()
+		c.Poll = &Poll{
+			Name:            poll.GetName(),
+			SelectableCount: poll.GetSelectableOptionsCount(),
+			Options:         make([]string, len(poll.GetOptions())),
+			EncKey:          msg.GetMessageContextInfo().GetMessageSecret(),
+		}
+		for i, opt := range poll.GetOptions() {
+			c.Poll.Options[i] = opt.GetOptionName()
+		}
+		ctxInfoSource = poll
+	case msg.PollUpdateMessage != nil:
+		c.Kind = KindPollVote
+		c.PollVote = &PollVote{
+			Poll:      refFromKey(msg.GetPollUpdateMessage().GetPollCreationMessageKey()),
+			Encrypted: msg.GetPollUpdateMessage().GetVote(),
+		}
+	case msg.ReactionMessage != nil:
+		c.Kind = KindReaction
+		c.Reaction = &Reaction{
+			Target:  refFromKey(msg.GetReactionMessage().GetKey()),
+			Emoji:   msg.GetReactionMessage().GetText(),
+			Removed: msg.GetReactionMessage().GetText() == "",
+		}
+	case msg.ProtocolMessage != nil:
+		protoMsg := msg.GetProtocolMessage()
+		switch protoMsg.GetType() {
+		case waE2E.ProtocolMessage_REVOKE:
+			c.Kind = KindRevoke
+			c.Revoke = &Revoke{Target: refFromKey(protoMsg.GetKey())}
+		case waE2E.ProtocolMessage_MESSAGE_EDIT:
+			c.Kind = KindEdit
+			c.Edit = &Edit{
+				Target:     refFromKey(protoMsg.GetKey()),
+				NewContent: Parse(protoMsg.GetEditedMessage()),
+			}
+			c.Body = c.Edit.NewContent.Body
+			c.Mentions = c.Edit.NewContent.Mentions
+		}
+	}
+	if ctxInfoSource != nil {
+		c.applyContextInfo(ctxInfoSource.GetContextInfo())
+	}
+	return c
+}
+
+func (c *Content) applyContextInfo(ctxInfo *waE2E.ContextInfo) {
+	if ctxInfo == nil {
+		return
+	}
+	for _, mention := range ctxInfo.GetMentionedJID() {
+		jid, err := types.ParseJID(mention)
+		if err == nil {
+			c.Mentions = append(c.Mentions, jid)
+		}
+	}
+	c.Forwarded = ctxInfo.GetIsForwarded()
+	c.ForwardingScore = ctxInfo.GetForwardingScore()
+	c.Expiration = time.Duration(ctxInfo.GetExpiration()) * time.Second
+	if ctxInfo.GetStanzaID() != "" {
+		c.ReplyTo = &Reply{
+			MessageRef: MessageRef{
+				ID:     ctxInfo.GetStanzaID(),
+				Chat:   parseJIDOrEmpty(ctxInfo.GetRemoteJID()),
+				Sender: parseJIDOrEmpty(ctxInfo.GetParticipant()),
+			},
+		}
+		if ctxInfo.GetQuotedMessage() != nil {
+			c.ReplyTo.Quoted = Parse(ctxInfo.GetQuotedMessage())
+		}
+	}
+}
+
+func parseJIDOrEmpty(jid string) types.JID {
+	if jid == "" {
+		return types.EmptyJID
+	}
+	parsed, err := types.ParseJID(jid)
+	if err != nil {
+		return types.EmptyJID
+	}
+	return parsed
+}
+
+func refFromKey(key *waCommon.MessageKey) MessageRef {
+	return MessageRef{
+		ID:     key.GetID(),
+		Chat:   parseJIDOrEmpty(key.GetRemoteJID()),
+		Sender: parseJIDOrEmpty(key.GetParticipant()),
+		FromMe: key.GetFromMe(),
+	}
+}
+
+// resolveKey fills the chat and sender of a key-based reference. Keys are relative to the sender of the
+// message containing them: FromMe means the target was sent by the same user.
+func (ref *MessageRef) resolveKey(info *types.MessageInfo) {
+	if ref.Chat.IsEmpty() {
+		ref.Chat = info.Chat
+	}
+	if ref.Sender.IsEmpty() {
+		if ref.FromMe {
+			ref.Sender = info.Sender
+		} else if !info.IsGroup && info.IsFromMe {
+			ref.Sender = info.Chat
+		}
+		// In incoming DMs, a key without FromMe points at a message sent by us,
+		// but our own JID isn't known here, so the sender is left empty (FromMe is set below).
+	}
+	// Convert FromMe to be relative to the user who received the message
+	if ref.FromMe {
+		ref.FromMe = info.IsFromMe
+	} else if !info.IsGroup {
+		ref.FromMe = !info.IsFromMe
+	}
+}
+
+func (c *Content) resolveRefs(info *types.MessageInfo) {
+	if c.ReplyTo != nil && c.ReplyTo.Chat.IsEmpty() {
+		c.ReplyTo.Chat = info.Chat
+	}
+	switch {
+	case c.Reaction != nil:
+		c.Reaction.Target.resolveKey(info)
+	case c.PollVote != nil:
+		c.PollVote.Poll.resolveKey(info)
+	case c.Revoke != nil:
+		c.Revoke.Target.resolveKey(info)
+	case c.Edit != nil:
+		c.Edit.Target.resolveKey(info)
+	}
+}
@@ -36,6 +36,12 @@ var (
 	ErrNoPrivacyToken = errors.New("no privacy token stored")
 
 	ErrAppStateUpdate = errors.New("server returned error updating app state")
+
+	ErrSleepModeNotEnabled = errors.New("sleep mode is not enabled")
+
+	ErrQuickRepliesUnsupported = errors.New("quick replies are not supported with this store")
+
+	ErrVerifiedIdentitiesUnsupported = errors.New("verified identities are not supported with this store")
 )
 
 // Errors that happen while confirming device pairing
@@ -102,11 +108,33 @@ var (
 	ErrUnknownMediaRetryError = errors.New("unknown media retry error")
 	// ErrInvalidDisappearingTimer is returned by SetDisappearingTimer if the given timer is not one of the allowed values.
 	ErrInvalidDisappearingTimer = errors.New("invalid disappearing timer provided")
+	// ErrNotCommunity is returned by community management methods if the given group is not a community parent group.
+	ErrNotCommunity = errors.New("that group is not a community")
+	// ErrNoAnnouncementGroup is returned by GetCommunityAnnouncementGroup if the community doesn't have an announcement group.
+	ErrNoAnnouncementGroup = errors.New("the community doesn't have an announcement group")
+	// ErrInvalidSecurityCodePayload is returned by VerifySecurityCodeQR if the scanned data isn't a valid security code payload.
+	ErrInvalidSecurityCodePayload = errors.New("invalid security code payload")
+	// ErrSecurityCodeVersion is returned by VerifySecurityCodeQR if the scanned payload uses an unsupported version.
+	ErrSecurityCodeVersion = errors.New("unsupported security code payload version")
+	// ErrIdentityKeyNotFound is returned by security code methods if the server didn't return the user's identity key.
+	ErrIdentityKeyNotFound = errors.New("identity key not found")
+	// ErrUnsupportedPasswordTransformer is returned by VerifyChatLockSecretCode if the secret code uses an unknown transformer.
+	ErrUnsupportedPasswordTransformer = errors.New("unsupported password transformer")
+	// ErrMissingPasswordTransformerArg is returned by VerifyChatLockSecretCode if the secret code is missing a required transformer argument.
+	ErrMissingPasswordTransformerArg = errors.New("missing password transformer argument")
+	// ErrMissingParticipantResult is returned in the results of UpdateGroupParticipantsBulk if the server didn't include a participant in the response.
+	ErrMissingParticipantResult = errors.New("server didn't return a result for participant")
+	// ErrMissingAddRequest is returned in the results of UpdateGroupParticipantsBulk if an invite message couldn't be sent,
+	// because the server didn't return an add request code for the participant.
+	ErrMissingAddRequest = errors.New("server didn't return an add request for participant")
+	// ErrInviteExpired is returned by JoinGroupFromInviteMessage if the invite message has already expired.
+	ErrInviteExpired = errors.New("group invite has expired")
 )
 
 // Some errors that Client.SendMessage can return
 var (
-	ErrBroadcastListUnsupported = errors.New("sending to non-status broadcast lists is not yet supported")
+	ErrBroadcastListUnsupported = errors.New("sending to non-status broadcast lists is not supported with this store")
+	ErrBroadcastListNotFound    = errors.New("broadcast list not found in store")
 	ErrUnknownServer            = errors.New("can't send message to unknown server")
 	ErrRecipientADJID           = errors.New("message recipient must be a user JID with no device part")
 	ErrServerReturnedError      = errors.New("server returned error")
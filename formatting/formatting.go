@@ -0,0 +1,357 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package formatting implements parsing and rendering WhatsApp's text formatting.
+//
+// WhatsApp text is parsed into a tree of nodes, which can be rendered back into WhatsApp text
+// or converted into HTML and Markdown. HTML and Markdown can also be converted into WhatsApp text.
+package formatting
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// NodeType is the type of a formatting node.
+type NodeType string
+
+// The known node types.
+const (
+	NodeText          NodeType = "text"
+	NodeBold          NodeType = "bold"
+	NodeItalic        NodeType = "italic"
+	NodeStrikethrough NodeType = "strikethrough"
+	NodeInlineCode    NodeType = "inline_code"
+	NodeCodeBlock     NodeType = "code_block"
+	NodeQuote         NodeType = "quote"
+	NodeBulletList    NodeType = "bullet_list"
+	NodeOrderedList   NodeType = "ordered_list"
+	NodeListItem      NodeType = "list_item"
+	NodeLineBreak     NodeType = "line_break"
+	NodeMention       NodeType = "mention"
+)
+
+// Node is a single entity in formatted text.
+type Node struct {
+	Type NodeType
+	// The raw text for text, inline code and code block nodes.
+	Text string
+	// The mentioned user for mention nodes.
+	JID types.JID
+	// The number of the item for list items in ordered lists.
+	Number int
+	// The child nodes for styles, quotes, lists and list items.
+	Children []*Node
+}
+
+// IsBlock returns true if the node is rendered on its own lines.
+func (n *Node) IsBlock() bool {
+	switch n.Type {
+	case NodeQuote, NodeBulletList, NodeOrderedList, NodeCodeBlock:
+		return true
+	default:
+		return false
+	}
+}
+
+// PlainText returns the text content of the nodes without any formatting.
+func PlainText(nodes []*Node) string {
+	var buf strings.Builder
+	writePlainText(&buf, nodes)
+	return buf.String()
+}
+
+func writePlainText(buf *strings.Builder, nodes []*Node) {
+	for i, node := range nodes {
+		switch node.Type {
+		case NodeText, NodeInlineCode, NodeCodeBlock:
+			buf.WriteString(node.Text)
+		case NodeMention:
+			buf.WriteString("@" + node.JID.User)
+		case NodeLineBreak:
+			buf.WriteByte('\n')
+		case NodeListItem:
+			if i > 0 {
+				buf.WriteByte('\n')
+			}
+			writePlainText(buf, node.Children)
+		default:
+			writePlainText(buf, node.Children)
+		}
+	}
+}
+
+// zeroWidthJoiner is inserted next to literal formatting characters when rendering text,
+// so that they aren't interpreted as formatting. A marker next to a joiner never opens or closes a span.
+const zeroWidthJoiner = '\u200d'
+
+// inlineMarkerChars are the characters that can open or close inline formatting in WhatsApp text.
+const inlineMarkerChars = "*_~`"
+
+// escapedMarkerChars are the characters that Render may put a zero width joiner after.
+const escapedMarkerChars = inlineMarkerChars + ">-."
+
+// isEscapeJoiner returns true if there's a zero width joiner at text[i] that was used to break formatting.
+func isEscapeJoiner(text string, i int) bool {
+	if !strings.HasPrefix(text[i:], string(zeroWidthJoiner)) {
+		return false
+	}
+	next := i + len(string(zeroWidthJoiner))
+	return (i > 0 && strings.IndexByte(escapedMarkerChars, text[i-1]) >= 0) ||
+		(next < len(text) && strings.IndexByte(inlineMarkerChars, text[next]) >= 0)
+}
+
+type inlineMarker struct {
+	marker string
+	typ    NodeType
+	// If true, the content is not parsed for further formatting.
+	raw bool
+}
+
+var whatsAppMarkers = []inlineMarker{
+	{"`", NodeInlineCode, true},
+	{"*", NodeBold, false},
+	{"_", NodeItalic, false},
+	{"~", NodeStrikethrough, false},
+}
+
+type parser struct {
+	markers []inlineMarker
+	// If true, backslash escapes are supported (Markdown).
+	escapes bool
+	// If true, [text](url) links are converted to text (Markdown).
+	links bool
+	// If true, lines starting with # are converted to bold text (Markdown).
+	headings bool
+
+	mentions map[string]types.JID
+}
+
+// Parse parses WhatsApp-formatted text into a node tree.
+//
+// Mentions are only parsed for the given JIDs, which should be the ones in ContextInfo.MentionedJID.
+// Other @numbers are left as plain text.
+//
+// Zero width joiners next to formatting characters are treated as escapes (see Render) and removed from the text.
+func Parse(text string, mentions ...types.JID) []*Node {
+	p := &parser{markers: whatsAppMarkers, mentions: mentionMap(mentions)}
+	return p.parse(text)
+}
+
+func mentionMap(mentions []types.JID) map[string]types.JID {
+	if len(mentions) == 0 {
+		return nil
+	}
+	m := make(map[string]types.JID, len(mentions))
+	for _, jid := range mentions {
+		m[jid.User] = jid
+	}
+	return m
+}
+
+func (p *parser) parse(text string) []*Node {
+	var nodes []*Node
+	atLineStart := true
+	for len(text) > 0 {
+		start := strings.Index(text, "```")
+		end := -1
+		if start >= 0 {
+			end = strings.Index(text[start+3:], "```")
+		}
+		if start < 0 || end <= 0 {
+			nodes = append(nodes, p.parseBlocks(text, atLineStart)...)
+			break
+		}
+		if start > 0 {
+			nodes = append(nodes, p.parseBlocks(text[:start], atLineStart)...)
+		}
+		nodes = append(nodes, &Node{Type: NodeCodeBlock, Text: text[start+3 : start+3+end]})
+		text = text[start+3+end+3:]
+		atLineStart = false
+	}
+	return nodes
+}
+
+var orderedListRegex = regexp.MustCompile(`^(\d+)\. `)
+
+func (p *parser) parseBlocks(text string, atLineStart bool) []*Node {
+	var nodes []*Node
+	var lastBlock *Node
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			if lastBlock == nil || !p.continuesBlock(lastBlock, line) {
+				nodes = append(nodes, &Node{Type: NodeLineBreak})
+				lastBlock = nil
+			}
+		}
+		lineStart := i > 0 || atLineStart
+		switch {
+		case lineStart && (strings.HasPrefix(line, "> ") || line == ">"):
+			content := p.parseInline(strings.TrimPrefix(strings.TrimPrefix(line, ">"), " "))
+			if lastBlock != nil && lastBlock.Type == NodeQuote {
+				lastBlock.Children = append(lastBlock.Children, &Node{Type: NodeLineBreak})
+				lastBlock.Children = append(lastBlock.Children, content...)
+			} else {
+				lastBlock = &Node{Type: NodeQuote, Children: content}
+				nodes = append(nodes, lastBlock)
+			}
+		case lineStart && (strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "- ")):
+			item := &Node{Type: NodeListItem, Children: p.parseInline(line[2:])}
+			if lastBlock != nil && lastBlock.Type == NodeBulletList {
+				lastBlock.Children = append(lastBlock.Children, item)
+			} else {
+				lastBlock = &Node{Type: NodeBulletList, Children: []*Node{item}}
+				nodes = append(nodes, lastBlock)
+			}
+		case lineStart && orderedListRegex.MatchString(line):
+			match := orderedListRegex.FindStringSubmatch(line)
+			num, _ := strconv.Atoi(match[1])
+			item := &Node{Type: NodeListItem, Number: num, Children: p.parseInline(line[len(match[0]):])}
+			if lastBlock != nil && lastBlock.Type == NodeOrderedList {
+				lastBlock.Children = append(lastBlock.Children, item)
+			} else {
+				lastBlock = &Node{Type: NodeOrderedList, Children: []*Node{item}}
+				nodes = append(nodes, lastBlock)
+			}
+		case lineStart && p.headings && strings.HasPrefix(line, "#"):
+			lastBlock = nil
+			trimmed := strings.TrimLeft(line, "#")
+			if len(trimmed) > 0 && trimmed[0] == ' ' {
+				nodes = append(nodes, &Node{Type: NodeBold, Children: p.parseInline(trimmed[1:])})
+			} else {
+				nodes = append(nodes, p.parseInline(line)...)
+			}
+		default:
+			lastBlock = nil
+			nodes = append(nodes, p.parseInline(line)...)
+		}
+	}
+	return nodes
+}
+
+func (p *parser) continuesBlock(block *Node, line string) bool {
+	switch block.Type {
+	case NodeQuote:
+		return strings.HasPrefix(line, "> ") || line == ">"
+	case NodeBulletList:
+		return strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "- ")
+	case NodeOrderedList:
+		return orderedListRegex.MatchString(line)
+	default:
+		return false
+	}
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (p *parser) canOpen(text string, i int, marker string) bool {
+	if i > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(text[:i])
+		if isWordChar(prev) {
+			return false
+		}
+	}
+	next, _ := utf8.DecodeRuneInString(text[i+len(marker):])
+	// The content can't start with the marker character, e.g. ****** is just text
+	return next != utf8.RuneError && next != zeroWidthJoiner && !unicode.IsSpace(next) && next != rune(marker[0])
+}
+
+func (p *parser) findClose(text string, start int, marker string) int {
+	for j := start; j < len(text); j++ {
+		if p.escapes && text[j] == '\\' {
+			j++
+			continue
+		}
+		if !strings.HasPrefix(text[j:], marker) || j == start {
+			continue
+		}
+		prev, _ := utf8.DecodeLastRuneInString(text[:j])
+		if unicode.IsSpace(prev) || prev == zeroWidthJoiner {
+			continue
+		}
+		next, _ := utf8.DecodeRuneInString(text[j+len(marker):])
+		if j+len(marker) < len(text) && isWordChar(next) {
+			continue
+		}
+		return j
+	}
+	return -1
+}
+
+func (p *parser) parseInline(text string) []*Node {
+	var nodes []*Node
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			nodes = append(nodes, &Node{Type: NodeText, Text: plain.String()})
+			plain.Reset()
+		}
+	}
+Outer:
+	for i := 0; i < len(text); i++ {
+		if p.escapes && text[i] == '\\' && i+1 < len(text) && strings.IndexByte(markdownEscapable, text[i+1]) >= 0 {
+			plain.WriteByte(text[i+1])
+			i++
+			continue
+		}
+		if isEscapeJoiner(text, i) {
+			i += len(string(zeroWidthJoiner)) - 1
+			continue
+		}
+		if p.links && text[i] == '[' {
+			if label, url, length, ok := parseMarkdownLink(text[i:]); ok {
+				flush()
+				nodes = append(nodes, p.parseInline(label)...)
+				if url != label {
+					nodes = append(nodes, &Node{Type: NodeText, Text: " (" + url + ")"})
+				}
+				i += length - 1
+				continue
+			}
+		}
+		if text[i] == '@' && p.mentions != nil {
+			end := i + 1
+			for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+				end++
+			}
+			if jid, ok := p.mentions[text[i+1:end]]; ok && end > i+1 {
+				flush()
+				nodes = append(nodes, &Node{Type: NodeMention, JID: jid})
+				i = end - 1
+				continue
+			}
+		}
+		for _, m := range p.markers {
+			if !strings.HasPrefix(text[i:], m.marker) || !p.canOpen(text, i, m.marker) {
+				continue
+			}
+			contentStart := i + len(m.marker)
+			closeIdx := p.findClose(text, contentStart, m.marker)
+			if closeIdx < 0 {
+				continue
+			}
+			flush()
+			content := text[contentStart:closeIdx]
+			if m.raw {
+				nodes = append(nodes, &Node{Type: m.typ, Text: content})
+			} else {
+				nodes = append(nodes, &Node{Type: m.typ, Children: p.parseInline(content)})
+			}
+			i = closeIdx + len(m.marker) - 1
+			continue Outer
+		}
+		plain.WriteByte(text[i])
+	}
+	flush()
+	return nodes
+}
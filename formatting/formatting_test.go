@@ -0,0 +1,200 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package formatting
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// dumpNodes formats the node tree in a compact form for comparing in tests.
+func dumpNodes(nodes []*Node) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		switch node.Type {
+		case NodeText, NodeInlineCode, NodeCodeBlock:
+			parts[i] = string(node.Type) + "(" + strconv.Quote(node.Text) + ")"
+		case NodeMention:
+			parts[i] = "mention(" + node.JID.User + ")"
+		case NodeLineBreak:
+			parts[i] = "br"
+		case NodeListItem:
+			parts[i] = "item" + strconv.Itoa(node.Number) + "(" + dumpNodes(node.Children) + ")"
+		default:
+			parts[i] = string(node.Type) + "(" + dumpNodes(node.Children) + ")"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func text(s string) *Node {
+	return &Node{Type: NodeText, Text: s}
+}
+
+func styled(typ NodeType, children ...*Node) *Node {
+	return &Node{Type: typ, Children: children}
+}
+
+var (
+	mentionedUser = types.NewJID("1234567890", types.DefaultUserServer)
+	otherUser     = types.NewJID("9876543210", types.DefaultUserServer)
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Styles", "*bold* _italic_ ~strike~ `code`", `bold(text("bold")) text(" ") italic(text("italic")) text(" ") strikethrough(text("strike")) text(" ") inline_code("code")`},
+		{"Nested", "*bold _both_*", `bold(text("bold ") italic(text("both")))`},
+		{"InsideWord", "a*b*c snake_case_name", `text("a*b*c snake_case_name")`},
+		{"OnlyMarkers", "******", `text("******")`},
+		{"DoubleMarkers", "**bold**", `text("*") bold(text("bold")) text("*")`},
+		{"Spaces", "2 * 3 * 4", `text("2 * 3 * 4")`},
+		{"Unclosed", "*bold", `text("*bold")`},
+		{"CodeBlock", "```*not bold*```", `code_block("*not bold*")`},
+		{"CodeBlockInText", "see ```a\nb``` ok", `text("see ") code_block("a\nb") text(" ok")`},
+		{"RawInlineCode", "`*a*`", `inline_code("*a*")`},
+		{"Quote", "> quoted\n> *more*\nafter", `quote(text("quoted") br bold(text("more"))) br text("after")`},
+		{"BulletList", "* one\n- two", `bullet_list(item0(text("one")) item0(text("two")))`},
+		{"OrderedList", "3. three\n4. four", `ordered_list(item3(text("three")) item4(text("four")))`},
+		{"Joiners", "*\u200dnot\u200d*", `text("*not*")`},
+		{"BlockJoiners", ">\u200d no\n-\u200d no\n1.\u200d no", `text("> no") br text("- no") br text("1. no")`},
+		{"EmojiJoiners", "👨\u200d👩 *x*", `text("👨\u200d👩 ") bold(text("x"))`},
+		{"Mentions", "@1234567890 and @9876543210", `mention(1234567890) text(" and @9876543210")`},
+		{"MentionInCode", "`@1234567890`", `inline_code("@1234567890")`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if output := dumpNodes(Parse(test.input, mentionedUser)); output != test.expected {
+				t.Errorf("Unexpected parse result for %q:\n got: %s\nwant: %s", test.input, output, test.expected)
+			}
+		})
+	}
+}
+
+func TestRender_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []*Node
+	}{
+		{"LiteralBold", []*Node{text("*not*")}},
+		{"LiteralMarkers", []*Node{text("_under_ and ~tilde~ and `tick`")}},
+		{"OnlyMarkers", []*Node{text("******")}},
+		{"LiteralCodeBlock", []*Node{text("```code```")}},
+		{"Arithmetic", []*Node{text("2 * 3 * 4 = 24*1")}},
+		{"MarkerAtEndOfStyle", []*Node{styled(NodeBold, text("x*"))}},
+		{"MarkerInsideStyle", []*Node{styled(NodeItalic, text("*"))}},
+		{"MarkerAfterStyle", []*Node{text("a "), styled(NodeBold, text("b")), text("*")}},
+		{"MarkerBeforeStyle", []*Node{text("_"), styled(NodeStrikethrough, text("s"))}},
+		{"Nested", []*Node{styled(NodeBold, text("a "), styled(NodeItalic, text("b")), text(" c"))}},
+		{"LiteralQuote", []*Node{text("> not a quote")}},
+		{"LiteralList", []*Node{text("- a"), {Type: NodeLineBreak}, text("* b"), {Type: NodeLineBreak}, text("1. c")}},
+		{"QuoteWithList", []*Node{styled(NodeQuote, text("- x"))}},
+		{"List", []*Node{styled(NodeBulletList, styled(NodeListItem, text("a")), styled(NodeListItem, styled(NodeBold, text("b"))))}},
+		{"Code", []*Node{text("see "), {Type: NodeInlineCode, Text: "a*b"}, text(" "), {Type: NodeCodeBlock, Text: "x\ny"}}},
+		{"Emoji", []*Node{text("👨\u200d👩 ok")}},
+		{"Mention", []*Node{{Type: NodeMention, JID: mentionedUser}, text(" said @9876543210")}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rendered := Render(test.nodes)
+			expected := dumpNodes(test.nodes)
+			if output := dumpNodes(Parse(rendered, mentionedUser)); output != expected {
+				t.Errorf("Round trip through %q changed nodes:\n got: %s\nwant: %s", rendered, output, expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownToWhatsApp(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Styles", "**bold** *italic* _italic_ ~~strike~~ `code`", "*bold* _italic_ _italic_ ~strike~ `code`"},
+		{"Nested", "**bold _both_**", "*bold _both_*"},
+		{"Escaped", `\*not\*`, "*\u200dnot\u200d*"},
+		{"EscapedUnderscores", `snake\_case\_name`, "snake_case_name"},
+		{"OnlyMarkers", `\*\*\*\*\*\*`, "*\u200d*\u200d*\u200d*\u200d*\u200d*"},
+		{"EscapedBlocks", "\\> no\n\\- no", ">\u200d no\n-\u200d no"},
+		{"FencedCode", "```go\nfmt.Println(\"*hi*\")\n```", "```fmt.Println(\"*hi*\")```"},
+		{"Lists", "- a\n- b\n\n1. one\n2. two", "* a\n* b\n\n1. one\n2. two"},
+		{"Quote", "> quoted\n> text", "> quoted\n> text"},
+		{"Heading", "# Title", "*Title*"},
+		{"Link", "[site](https://example.com) [https://example.com](https://example.com)", "site (https://example.com) https://example.com"},
+		{"Mention", "hi @1234567890", "hi @1234567890"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if output := MarkdownToWhatsApp(test.input); output != test.expected {
+				t.Errorf("Unexpected output for %q:\n got: %q\nwant: %q", test.input, output, test.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdown_RoundTrip(t *testing.T) {
+	tests := []string{
+		"*bold* _italic_ ~strike~ `code`",
+		"*bold _both_*",
+		"```code block```",
+		"> quoted\n> text",
+		"* a\n* b",
+		"1. one\n2. two",
+		"*\u200dnot\u200d* bold",
+	}
+	for _, input := range tests {
+		markdown := WhatsAppToMarkdown(input, nil)
+		if output := MarkdownToWhatsApp(markdown); output != input {
+			t.Errorf("Round trip of %q through Markdown %q returned %q", input, markdown, output)
+		}
+	}
+}
+
+func TestParseMarkdown_NoMentions(t *testing.T) {
+	if mentions := Mentions(ParseMarkdown("hi @1234567890")); len(mentions) != 0 {
+		t.Errorf("Markdown parser returned mentions %v", mentions)
+	}
+}
+
+func TestInjectMentions(t *testing.T) {
+	output, mentions := InjectMentions("@alice2 and @alice", map[string]types.JID{
+		"alice":  mentionedUser,
+		"alice2": otherUser,
+	})
+	if output != "@9876543210 and @1234567890" {
+		t.Errorf("Unexpected text %q", output)
+	} else if !slices.Equal(mentions, []types.JID{otherUser, mentionedUser}) {
+		t.Errorf("Unexpected mentions %v", mentions)
+	}
+}
+
+func TestExtractMentions(t *testing.T) {
+	mentions := ExtractMentions("`@1234567890` ```@1234567890``` foo@1234567890 @9876543210", types.DefaultUserServer)
+	if !slices.Equal(mentions, []types.JID{otherUser}) {
+		t.Errorf("Unexpected mentions %v", mentions)
+	}
+}
+
+func TestBuildTextMessage(t *testing.T) {
+	msg := BuildTextMessage("hi @1234567890", mentionedUser, otherUser)
+	if msg.GetExtendedTextMessage().GetText() != "hi @1234567890" {
+		t.Fatalf("Unexpected message %v", msg)
+	} else if mentioned := msg.GetExtendedTextMessage().GetContextInfo().GetMentionedJID(); !slices.Equal(mentioned, []string{mentionedUser.String()}) {
+		t.Errorf("Unexpected mentioned JIDs %v", mentioned)
+	}
+	if msg = BuildTextMessage("hi", otherUser); msg.GetConversation() != "hi" {
+		t.Errorf("Message without mentions in text wasn't a plain conversation: %v", msg)
+	}
+}
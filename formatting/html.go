@@ -0,0 +1,266 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package formatting
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// HTMLOptions contains options for rendering HTML.
+type HTMLOptions struct {
+	// If set, this is called to render mentions. The default renders a wa.me link with the @number as text.
+	Mention func(jid types.JID) string
+}
+
+// RenderHTML renders the nodes as HTML.
+func RenderHTML(nodes []*Node, opts *HTMLOptions) string {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+	var buf strings.Builder
+	renderHTML(&buf, nodes, opts)
+	return buf.String()
+}
+
+// WhatsAppToHTML converts WhatsApp-formatted text into HTML.
+func WhatsAppToHTML(text string, mentions []types.JID, opts *HTMLOptions) string {
+	return RenderHTML(Parse(text, mentions...), opts)
+}
+
+func defaultMentionHTML(jid types.JID) string {
+	if jid.Server == types.DefaultUserServer {
+		return `<a href="https://wa.me/` + html.EscapeString(jid.User) + `">@` + html.EscapeString(jid.User) + `</a>`
+	}
+	return "@" + html.EscapeString(jid.User)
+}
+
+func renderHTML(buf *strings.Builder, nodes []*Node, opts *HTMLOptions) {
+	for i, node := range nodes {
+		switch node.Type {
+		case NodeText:
+			buf.WriteString(html.EscapeString(node.Text))
+		case NodeBold:
+			writeHTMLTag(buf, "strong", node.Children, opts)
+		case NodeItalic:
+			writeHTMLTag(buf, "em", node.Children, opts)
+		case NodeStrikethrough:
+			writeHTMLTag(buf, "del", node.Children, opts)
+		case NodeInlineCode:
+			buf.WriteString("<code>" + html.EscapeString(node.Text) + "</code>")
+		case NodeCodeBlock:
+			buf.WriteString("<pre><code>" + html.EscapeString(node.Text) + "</code></pre>")
+		case NodeMention:
+			if opts.Mention != nil {
+				buf.WriteString(opts.Mention(node.JID))
+			} else {
+				buf.WriteString(defaultMentionHTML(node.JID))
+			}
+		case NodeLineBreak:
+			// Block elements already start on a new line
+			if i == 0 || !nodes[i-1].IsBlock() {
+				buf.WriteString("<br>")
+			}
+		case NodeQuote:
+			writeHTMLTag(buf, "blockquote", node.Children, opts)
+		case NodeBulletList:
+			writeHTMLTag(buf, "ul", node.Children, opts)
+		case NodeOrderedList:
+			buf.WriteString("<ol")
+			if len(node.Children) > 0 && node.Children[0].Number > 1 {
+				buf.WriteString(` start="` + strconv.Itoa(node.Children[0].Number) + `"`)
+			}
+			buf.WriteString(">")
+			renderHTML(buf, node.Children, opts)
+			buf.WriteString("</ol>")
+		case NodeListItem:
+			writeHTMLTag(buf, "li", node.Children, opts)
+		}
+	}
+}
+
+func writeHTMLTag(buf *strings.Builder, tag string, children []*Node, opts *HTMLOptions) {
+	buf.WriteString("<" + tag + ">")
+	renderHTML(buf, children, opts)
+	buf.WriteString("</" + tag + ">")
+}
+
+var waMeLinkRegex = regexp.MustCompile(`^https://wa\.me/(\d+)/?$`)
+
+// ParseHTML converts HTML into formatting nodes.
+//
+// Links to wa.me/<number> are converted into mentions. Other links are converted into text with the URL in parentheses.
+// Unsupported tags are ignored, but their contents are kept.
+func ParseHTML(input string) ([]*Node, error) {
+	root, err := xhtml.Parse(strings.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	conv := &htmlConverter{}
+	nodes := conv.convertChildren(root)
+	return trimLineBreaks(nodes), nil
+}
+
+// HTMLToWhatsApp converts HTML into WhatsApp-formatted text and returns the mentioned users.
+func HTMLToWhatsApp(input string) (string, []types.JID, error) {
+	nodes, err := ParseHTML(input)
+	if err != nil {
+		return "", nil, err
+	}
+	return Render(nodes), Mentions(nodes), nil
+}
+
+type htmlConverter struct {
+	inPre bool
+}
+
+func (conv *htmlConverter) convertChildren(node *xhtml.Node) []*Node {
+	var nodes []*Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		nodes = append(nodes, conv.convert(child)...)
+	}
+	return nodes
+}
+
+func textContent(node *xhtml.Node) string {
+	if node.Type == xhtml.TextNode {
+		return node.Data
+	}
+	var buf strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		buf.WriteString(textContent(child))
+	}
+	return buf.String()
+}
+
+func getAttr(node *xhtml.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func (conv *htmlConverter) convert(node *xhtml.Node) []*Node {
+	switch node.Type {
+	case xhtml.TextNode:
+		if conv.inPre {
+			return []*Node{{Type: NodeText, Text: node.Data}}
+		}
+		text := strings.Join(strings.Fields(node.Data), " ")
+		if text == "" && node.Data != "" {
+			text = " "
+		} else if text != "" {
+			if strings.TrimLeft(node.Data[:1], " \t\r\n") == "" {
+				text = " " + text
+			}
+			if strings.TrimRight(node.Data[len(node.Data)-1:], " \t\r\n") == "" {
+				text += " "
+			}
+		}
+		return []*Node{{Type: NodeText, Text: text}}
+	case xhtml.ElementNode:
+	case xhtml.DocumentNode:
+		return conv.convertChildren(node)
+	default:
+		return nil
+	}
+	switch node.DataAtom {
+	case atom.B, atom.Strong:
+		return []*Node{{Type: NodeBold, Children: conv.convertChildren(node)}}
+	case atom.I, atom.Em:
+		return []*Node{{Type: NodeItalic, Children: conv.convertChildren(node)}}
+	case atom.S, atom.Del, atom.Strike:
+		return []*Node{{Type: NodeStrikethrough, Children: conv.convertChildren(node)}}
+	case atom.Code:
+		if conv.inPre {
+			return []*Node{{Type: NodeText, Text: textContent(node)}}
+		}
+		return []*Node{{Type: NodeInlineCode, Text: textContent(node)}}
+	case atom.Pre:
+		return []*Node{{Type: NodeCodeBlock, Text: strings.TrimSuffix(textContent(node), "\n")}}
+	case atom.Br:
+		return []*Node{{Type: NodeLineBreak}}
+	case atom.Blockquote:
+		return conv.wrapBlock(&Node{Type: NodeQuote, Children: trimLineBreaks(conv.convertChildren(node))})
+	case atom.Ul, atom.Ol:
+		list := &Node{Type: NodeBulletList}
+		number := 1
+		if node.DataAtom == atom.Ol {
+			list.Type = NodeOrderedList
+			if start, err := strconv.Atoi(getAttr(node, "start")); err == nil {
+				number = start
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != xhtml.ElementNode || child.DataAtom != atom.Li {
+				continue
+			}
+			item := &Node{Type: NodeListItem, Children: trimLineBreaks(conv.convertChildren(child))}
+			if list.Type == NodeOrderedList {
+				item.Number = number
+				number++
+			}
+			list.Children = append(list.Children, item)
+		}
+		return conv.wrapBlock(list)
+	case atom.P, atom.Div, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		children := trimLineBreaks(conv.convertChildren(node))
+		if node.DataAtom != atom.P && node.DataAtom != atom.Div {
+			children = []*Node{{Type: NodeBold, Children: children}}
+		}
+		return append(append([]*Node{{Type: NodeLineBreak}}, children...), &Node{Type: NodeLineBreak})
+	case atom.A:
+		href := getAttr(node, "href")
+		if match := waMeLinkRegex.FindStringSubmatch(href); match != nil {
+			return []*Node{{Type: NodeMention, JID: types.NewJID(match[1], types.DefaultUserServer)}}
+		}
+		children := conv.convertChildren(node)
+		if href != "" && href != strings.TrimSpace(PlainText(children)) {
+			children = append(children, &Node{Type: NodeText, Text: " (" + href + ")"})
+		}
+		return children
+	case atom.Script, atom.Style, atom.Head:
+		return nil
+	default:
+		return conv.convertChildren(node)
+	}
+}
+
+func (conv *htmlConverter) wrapBlock(block *Node) []*Node {
+	return []*Node{{Type: NodeLineBreak}, block, {Type: NodeLineBreak}}
+}
+
+// trimLineBreaks removes leading, trailing and duplicate line breaks that come from converting block elements.
+func trimLineBreaks(nodes []*Node) []*Node {
+	out := make([]*Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Type == NodeLineBreak {
+			if len(out) == 0 || out[len(out)-1].Type == NodeLineBreak {
+				continue
+			}
+		} else if node.Type == NodeText && len(out) > 0 && out[len(out)-1].Type == NodeLineBreak {
+			node.Text = strings.TrimLeft(node.Text, " ")
+			if node.Text == "" {
+				continue
+			}
+		}
+		out = append(out, node)
+	}
+	for len(out) > 0 && out[len(out)-1].Type == NodeLineBreak {
+		out = out[:len(out)-1]
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package formatting
+
+import (
+	"strconv"
+	"strings"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+const markdownEscapable = "\\`*_~[]()#>-.!|"
+
+var markdownMarkers = []inlineMarker{
+	{"`", NodeInlineCode, true},
+	{"**", NodeBold, false},
+	{"__", NodeBold, false},
+	{"~~", NodeStrikethrough, false},
+	{"*", NodeItalic, false},
+	{"_", NodeItalic, false},
+}
+
+// ParseMarkdown parses a subset of Markdown into formatting nodes.
+//
+// Supported syntax includes bold, italic, strikethrough, inline code, fenced code blocks, quotes, lists,
+// headings (converted to bold text) and links (converted to text with the URL in parentheses).
+func ParseMarkdown(input string) []*Node {
+	p := &parser{markers: markdownMarkers, escapes: true, links: true, headings: true}
+	nodes := p.parse(input)
+	for _, node := range nodes {
+		if node.Type == NodeCodeBlock {
+			node.Text = trimFencedCode(node.Text)
+		}
+	}
+	return nodes
+}
+
+// MarkdownToWhatsApp converts Markdown into WhatsApp-formatted text.
+func MarkdownToWhatsApp(input string) string {
+	return Render(ParseMarkdown(input))
+}
+
+// WhatsAppToMarkdown converts WhatsApp-formatted text into Markdown.
+func WhatsAppToMarkdown(text string, mentions []types.JID) string {
+	return RenderMarkdown(Parse(text, mentions...))
+}
+
+func trimFencedCode(code string) string {
+	// Remove the language identifier and the newlines around the code
+	if firstLine, rest, ok := strings.Cut(code, "\n"); ok && !strings.ContainsAny(firstLine, " \t") {
+		code = rest
+	}
+	return strings.TrimSuffix(code, "\n")
+}
+
+func parseMarkdownLink(text string) (label, url string, length int, ok bool) {
+	labelEnd := strings.Index(text, "](")
+	if labelEnd < 0 {
+		return
+	}
+	urlEnd := strings.IndexByte(text[labelEnd+2:], ')')
+	if urlEnd < 0 {
+		return
+	}
+	label = text[1:labelEnd]
+	url = text[labelEnd+2 : labelEnd+2+urlEnd]
+	if strings.ContainsAny(label, "\n") || strings.ContainsAny(url, " \n") {
+		return "", "", 0, false
+	}
+	return label, url, labelEnd + 2 + urlEnd + 1, true
+}
+
+func escapeMarkdown(text string) string {
+	var buf strings.Builder
+	for i := 0; i < len(text); i++ {
+		if strings.IndexByte("\\`*_~[]", text[i]) >= 0 {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(text[i])
+	}
+	return buf.String()
+}
+
+// RenderMarkdown renders the nodes as Markdown.
+func RenderMarkdown(nodes []*Node) string {
+	var buf strings.Builder
+	renderMarkdown(&buf, nodes)
+	return buf.String()
+}
+
+func renderMarkdown(buf *strings.Builder, nodes []*Node) {
+	for _, node := range nodes {
+		switch node.Type {
+		case NodeText:
+			buf.WriteString(escapeMarkdown(node.Text))
+		case NodeBold:
+			writeMarkdownWrapped(buf, "**", node.Children)
+		case NodeItalic:
+			writeMarkdownWrapped(buf, "_", node.Children)
+		case NodeStrikethrough:
+			writeMarkdownWrapped(buf, "~~", node.Children)
+		case NodeInlineCode:
+			buf.WriteString("`" + node.Text + "`")
+		case NodeCodeBlock:
+			buf.WriteString("```\n" + node.Text + "\n```")
+		case NodeMention:
+			buf.WriteString("@" + node.JID.User)
+		case NodeLineBreak:
+			buf.WriteByte('\n')
+		case NodeQuote:
+			lines := strings.Split(RenderMarkdown(node.Children), "\n")
+			for i, line := range lines {
+				if i > 0 {
+					buf.WriteByte('\n')
+				}
+				buf.WriteString("> " + line)
+			}
+		case NodeBulletList, NodeOrderedList:
+			for i, item := range node.Children {
+				if i > 0 {
+					buf.WriteByte('\n')
+				}
+				if node.Type == NodeOrderedList {
+					buf.WriteString(strconv.Itoa(listItemNumber(item, i)) + ". ")
+				} else {
+					buf.WriteString("- ")
+				}
+				renderMarkdown(buf, item.Children)
+			}
+		case NodeListItem:
+			renderMarkdown(buf, node.Children)
+		}
+	}
+}
+
+func writeMarkdownWrapped(buf *strings.Builder, marker string, children []*Node) {
+	buf.WriteString(marker)
+	renderMarkdown(buf, children)
+	buf.WriteString(marker)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package formatting
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Mentions returns the users mentioned in the nodes in the order they appear, without duplicates.
+func Mentions(nodes []*Node) []types.JID {
+	var mentions []types.JID
+	var walk func(nodes []*Node)
+	walk = func(nodes []*Node) {
+		for _, node := range nodes {
+			if node.Type == NodeMention && !slices.Contains(mentions, node.JID) {
+				mentions = append(mentions, node.JID)
+			}
+			walk(node.Children)
+		}
+	}
+	walk(nodes)
+	return mentions
+}
+
+var mentionRegex = regexp.MustCompile(`(?:^|[^\p{L}\p{N}])@(\d{5,20})`)
+
+// ExtractMentions finds @numbers in the text and returns them as JIDs on the given server
+// (usually types.DefaultUserServer or types.HiddenUserServer), in the order they appear, without duplicates.
+//
+// Mentions inside code are ignored.
+func ExtractMentions(text string, server string) []types.JID {
+	var mentions []types.JID
+	for _, node := range Parse(text) {
+		mentions = appendTextMentions(mentions, node, server)
+	}
+	return mentions
+}
+
+func appendTextMentions(mentions []types.JID, node *Node, server string) []types.JID {
+	switch node.Type {
+	case NodeText:
+		for _, match := range mentionRegex.FindAllStringSubmatch(node.Text, -1) {
+			jid := types.NewJID(match[1], server)
+			if !slices.Contains(mentions, jid) {
+				mentions = append(mentions, jid)
+			}
+		}
+	case NodeInlineCode, NodeCodeBlock:
+	default:
+		for _, child := range node.Children {
+			mentions = appendTextMentions(mentions, child, server)
+		}
+	}
+	return mentions
+}
+
+// InjectMentions replaces @name placeholders in the text with the @number format that WhatsApp uses,
+// and returns the new text along with the mentioned users.
+//
+// The names map contains the placeholder names without the @ prefix. Longer names are replaced first,
+// so that names that are prefixes of other names don't break the longer ones.
+func InjectMentions(text string, names map[string]types.JID) (string, []types.JID) {
+	keys := make([]string, 0, len(names))
+	for name := range names {
+		keys = append(keys, name)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return len(keys[i]) > len(keys[j])
+	})
+	var mentions []types.JID
+	for _, name := range keys {
+		placeholder := "@" + name
+		if !strings.Contains(text, placeholder) {
+			continue
+		}
+		jid := names[name]
+		text = strings.ReplaceAll(text, placeholder, "@"+jid.User)
+		if !slices.Contains(mentions, jid) {
+			mentions = append(mentions, jid)
+		}
+	}
+	return text, mentions
+}
+
+// FilterMentions returns the JIDs whose @number is present in the text.
+//
+// This can be used to keep ContextInfo.MentionedJID in sync with the text, e.g. after editing a message.
+func FilterMentions(text string, mentions []types.JID) []types.JID {
+	return Mentions(Parse(text, mentions...))
+}
+
+// BuildTextMessage builds a text message with the given mentions.
+//
+// Mentions whose @number isn't in the text are dropped, as WhatsApp clients only highlight mentions present in the text.
+// If there are no mentions, a plain conversation message is returned.
+func BuildTextMessage(text string, mentions ...types.JID) *waE2E.Message {
+	mentions = FilterMentions(text, mentions)
+	if len(mentions) == 0 {
+		return &waE2E.Message{Conversation: proto.String(text)}
+	}
+	mentionStrings := make([]string, len(mentions))
+	for i, jid := range mentions {
+		mentionStrings[i] = jid.String()
+	}
+	return &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String(text),
+			ContextInfo: &waE2E.ContextInfo{
+				MentionedJID: mentionStrings,
+			},
+		},
+	}
+}
+
+// BuildMessage renders the nodes into WhatsApp text and builds a message with the mentions in the nodes.
+func BuildMessage(nodes []*Node) *waE2E.Message {
+	return BuildTextMessage(Render(nodes), Mentions(nodes)...)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package formatting
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Render renders the nodes back into WhatsApp-formatted text.
+//
+// Formatting characters in text nodes that would otherwise open or close a span (or start a quote or list)
+// are broken with a zero width joiner, so that the text is displayed literally.
+func Render(nodes []*Node) string {
+	var buf strings.Builder
+	renderWhatsApp(&buf, nodes, 0)
+	return buf.String()
+}
+
+// firstRune returns the first character that the node is rendered as, or 0 if it's not known.
+func firstRune(node *Node) rune {
+	switch node.Type {
+	case NodeText:
+		r, _ := utf8.DecodeRuneInString(node.Text)
+		if r == utf8.RuneError {
+			return 0
+		}
+		return r
+	case NodeBold:
+		return '*'
+	case NodeItalic:
+		return '_'
+	case NodeStrikethrough:
+		return '~'
+	case NodeInlineCode, NodeCodeBlock:
+		return '`'
+	case NodeMention:
+		return '@'
+	case NodeLineBreak:
+		return '\n'
+	default:
+		return 0
+	}
+}
+
+// renderWhatsApp renders the nodes into the buffer. The after parameter is the character that will be written after
+// the nodes (e.g. the closing marker of the parent node), or 0 if it's not known.
+func renderWhatsApp(buf *strings.Builder, nodes []*Node, after rune) {
+	for i, node := range nodes {
+		switch node.Type {
+		case NodeText:
+			next := after
+			if i+1 < len(nodes) {
+				next = firstRune(nodes[i+1])
+			}
+			writeEscapedText(buf, node.Text, next)
+		case NodeBold:
+			writeWrapped(buf, "*", node.Children)
+		case NodeItalic:
+			writeWrapped(buf, "_", node.Children)
+		case NodeStrikethrough:
+			writeWrapped(buf, "~", node.Children)
+		case NodeInlineCode:
+			buf.WriteString("`" + node.Text + "`")
+		case NodeCodeBlock:
+			buf.WriteString("```" + node.Text + "```")
+		case NodeMention:
+			buf.WriteString("@" + node.JID.User)
+		case NodeLineBreak:
+			buf.WriteByte('\n')
+		case NodeQuote:
+			lines := strings.Split(Render(node.Children), "\n")
+			for i, line := range lines {
+				if i > 0 {
+					buf.WriteByte('\n')
+				}
+				buf.WriteString("> " + line)
+			}
+		case NodeBulletList, NodeOrderedList:
+			for i, item := range node.Children {
+				if i > 0 {
+					buf.WriteByte('\n')
+				}
+				if node.Type == NodeOrderedList {
+					buf.WriteString(strconv.Itoa(listItemNumber(item, i)) + ". ")
+				} else {
+					buf.WriteString("* ")
+				}
+				renderWhatsApp(buf, item.Children, 0)
+			}
+		case NodeListItem:
+			renderWhatsApp(buf, node.Children, 0)
+		}
+	}
+}
+
+func writeWrapped(buf *strings.Builder, marker string, children []*Node) {
+	buf.WriteString(marker)
+	renderWhatsApp(buf, children, rune(marker[0]))
+	buf.WriteString(marker)
+}
+
+// blockMarkerLength returns the length of the quote or list marker at the start of the line, or 0 if there isn't one.
+// The dot is included for ordered lists, but the space after the marker isn't.
+func blockMarkerLength(line string) int {
+	switch {
+	case strings.HasPrefix(line, "> ") || line == ">" || strings.HasPrefix(line, ">\n"):
+		return 1
+	case strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "- "):
+		return 1
+	}
+	if match := orderedListRegex.FindString(line); match != "" {
+		return len(match) - 1
+	}
+	return 0
+}
+
+// writeEscapedText writes plain text into the buffer, inserting zero width joiners next to formatting characters that
+// the parser would otherwise treat as markers. The next parameter is the character after the text, or 0 if it's not known.
+//
+// Joiners are only added on the side facing the text itself, so that they don't break markers of the surrounding nodes.
+func writeEscapedText(buf *strings.Builder, text string, next rune) {
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if buf.Len() == 0 || strings.HasSuffix(buf.String(), "\n") {
+			if length := blockMarkerLength(text[i:]); length > 0 {
+				buf.WriteString(text[i : i+length])
+				buf.WriteRune(zeroWidthJoiner)
+				i += length - 1
+				continue
+			}
+		}
+		if strings.IndexByte(inlineMarkerChars, c) < 0 {
+			buf.WriteByte(c)
+			continue
+		}
+		prev, _ := utf8.DecodeLastRuneInString(buf.String())
+		hasPrev := buf.Len() > 0
+		nextChar, hasNext := next, next != 0
+		if i+1 < len(text) {
+			nextChar, _ = utf8.DecodeRuneInString(text[i+1:])
+			hasNext = true
+		}
+		couldClose := hasPrev && prev != zeroWidthJoiner && !unicode.IsSpace(prev) && (!hasNext || !isWordChar(nextChar))
+		couldOpen := (!hasPrev || !isWordChar(prev)) && hasNext && !unicode.IsSpace(nextChar) && nextChar != rune(c)
+		if couldClose && (i > 0 || !strings.ContainsRune(inlineMarkerChars, prev)) {
+			buf.WriteRune(zeroWidthJoiner)
+		}
+		buf.WriteByte(c)
+		if couldOpen && (i+1 < len(text) || !strings.ContainsRune(inlineMarkerChars, nextChar)) {
+			buf.WriteRune(zeroWidthJoiner)
+		}
+	}
+}
+
+func listItemNumber(item *Node, index int) int {
+	if item.Number > 0 {
+		return item.Number
+	}
+	return index + 1
+}
@@ -11,8 +11,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
 
 	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
@@ -452,6 +456,68 @@ func (cli *Client) JoinGroupWithInvite(ctx context.Context, jid, inviter types.J
 	return err
 }
 
+// GetGroupInfoFromInviteMessage asks the WhatsApp servers for info about the group in the given invite message.
+// This will not cause the user to join the group.
+//
+// The inviter is the sender of the message that contained the invite.
+func (cli *Client) GetGroupInfoFromInviteMessage(ctx context.Context, inviter types.JID, msg *waE2E.GroupInviteMessage) (*types.GroupInfo, error) {
+	groupJID, err := types.ParseJID(msg.GetGroupJID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse group JID in invite message: %w", err)
+	}
+	return cli.GetGroupInfoFromInvite(ctx, groupJID, inviter, msg.GetInviteCode(), msg.GetInviteExpiration())
+}
+
+// JoinGroupFromInviteMessage joins the group in the given invite message and returns the group JID.
+//
+// The inviter is the sender of the message that contained the invite.
+func (cli *Client) JoinGroupFromInviteMessage(ctx context.Context, inviter types.JID, msg *waE2E.GroupInviteMessage) (types.JID, error) {
+	groupJID, err := types.ParseJID(msg.GetGroupJID())
+	if err != nil {
+		return types.EmptyJID, fmt.Errorf("failed to parse group JID in invite message: %w", err)
+	} else if msg.GetInviteExpiration() > 0 && time.Unix(msg.GetInviteExpiration(), 0).Before(time.Now()) {
+		return groupJID, ErrInviteExpired
+	}
+	return groupJID, cli.JoinGroupWithInvite(ctx, groupJID, inviter, msg.GetInviteCode(), msg.GetInviteExpiration())
+}
+
+// BuildGroupInviteMessage builds a message that invites a specific user to a group.
+//
+// The add request is returned by UpdateGroupParticipants in the AddRequest field of participants
+// that couldn't be added directly because of their privacy settings (error code 403).
+// The message must be sent to the same user that the add request is for.
+func (cli *Client) BuildGroupInviteMessage(group types.JID, groupName string, addRequest types.GroupParticipantAddRequest, caption string) *waE2E.Message {
+	msg := &waE2E.Message{
+		GroupInviteMessage: &waE2E.GroupInviteMessage{
+			GroupJID:   proto.String(group.String()),
+			InviteCode: proto.String(addRequest.Code),
+			GroupName:  proto.String(groupName),
+			Caption:    proto.String(caption),
+		},
+	}
+	if !addRequest.Expiration.IsZero() {
+		msg.GroupInviteMessage.InviteExpiration = proto.Int64(addRequest.Expiration.Unix())
+	}
+	return msg
+}
+
+// RevokeGroupInvite revokes the user-specific invites sent to the given users after they couldn't be added directly.
+// The invite messages that were already sent will no longer work.
+func (cli *Client) RevokeGroupInvite(ctx context.Context, group types.JID, participants []types.JID) error {
+	content := make([]waBinary.Node, len(participants))
+	for i, participant := range participants {
+		content[i] = waBinary.Node{
+			Tag:   "participant",
+			Attrs: waBinary.Attrs{"jid": participant},
+		}
+	}
+	_, err := cli.sendGroupIQ(ctx, iqSet, group, waBinary.Node{
+		Tag:     "revoke",
+		Content: content,
+	})
+	return err
+}
+
 // GetGroupInfoFromLink resolves the given invite link and asks the WhatsApp servers for info about the group.
 // This will not cause the user to join the group.
 func (cli *Client) GetGroupInfoFromLink(ctx context.Context, code string) (*types.GroupInfo, error) {
@@ -588,7 +654,19 @@ func (cli *Client) GetLinkedGroupsParticipants(ctx context.Context, community ty
 }
 
 // GetGroupInfo requests basic info about a group chat from the WhatsApp servers.
+//
+// If [Client.CacheFirstGroupInfo] is set, the group info will be returned from the local store if it's available there
+// and it was fetched from the server within MaxStoredGroupInfoAge.
+// Use GetGroupInfoFromServer to always fetch the latest info from the server.
 func (cli *Client) GetGroupInfo(ctx context.Context, jid types.JID) (*types.GroupInfo, error) {
+	if info := cli.getFreshStoredGroupInfo(ctx, jid); info != nil {
+		return info, nil
+	}
+	return cli.getGroupInfo(ctx, jid, true)
+}
+
+// GetGroupInfoFromServer requests basic info about a group chat from the WhatsApp servers, ignoring any locally cached info.
+func (cli *Client) GetGroupInfoFromServer(ctx context.Context, jid types.JID) (*types.GroupInfo, error) {
 	return cli.getGroupInfo(ctx, jid, true)
 }
 
@@ -645,6 +723,7 @@ func (cli *Client) getGroupInfo(ctx context.Context, jid types.JID, lockParticip
 		return groupInfo, err
 	}
 	lidPairs, redactedPhones := cli.cacheGroupInfo(groupInfo, lockParticipantCache)
+	cli.storeGroupInfo(ctx, groupInfo, time.Now())
 	err = cli.Store.LIDs.PutManyLIDMappings(ctx, lidPairs)
 	if err != nil {
 		cli.Log.Warnf("Failed to store LID mappings for members of %s: %v", jid, err)
@@ -662,6 +741,10 @@ func (cli *Client) getCachedGroupData(ctx context.Context, jid types.JID) (*grou
 	if val, ok := cli.groupCache[jid]; ok {
 		return val, nil
 	}
+	if info := cli.getFreshStoredGroupInfo(ctx, jid); info != nil {
+		cli.cacheGroupInfo(info, false)
+		return cli.groupCache[jid], nil
+	}
 	_, err := cli.getGroupInfo(ctx, jid, false)
 	if err != nil {
 		return nil, err
@@ -684,6 +767,11 @@ func parseParticipant(childAG *waBinary.AttrUtility, child *waBinary.Node) types
 		participant.PhoneNumber = participant.JID
 		participant.LID = childAG.OptionalJIDOrEmpty("lid")
 	}
+	if label, ok := child.GetOptionalChildByTag("participant_label"); ok {
+		labelBytes, _ := label.Content.([]byte)
+		participant.Label = string(labelBytes)
+		participant.LabelSetAt = label.AttrGetter().OptionalUnixTime("t")
+	}
 	if errorCode := childAG.OptionalInt("error"); errorCode != 0 {
 		participant.Error = errorCode
 		addRequest, ok := child.GetOptionalChildByTag("add_request")
@@ -744,6 +832,15 @@ func (cli *Client) parseGroupNode(groupNode *waBinary.Node) (*types.GroupInfo, e
 		case "member_add_mode":
 			modeBytes, _ := child.Content.([]byte)
 			group.MemberAddMode = types.GroupMemberAddMode(modeBytes)
+		case "member_link_mode":
+			modeBytes, _ := child.Content.([]byte)
+			group.MemberLinkMode = types.GroupMemberLinkMode(modeBytes)
+		case "participant_label_enabled":
+			group.IsParticipantLabelEnabled = true
+		case "group_safety_check":
+			group.IsSafetyCheckEnabled = true
+		case "hidden_group":
+			group.IsHidden = true
 		case "linked_parent":
 			group.LinkedParentJID = childAG.JID("jid")
 		case "default_sub_group":
@@ -955,6 +1052,10 @@ func (cli *Client) parseGroupChange(node *waBinary.Node) (*events.GroupInfo, []s
 			evt.MembershipApprovalMode = &types.GroupMembershipApprovalMode{
 				IsJoinApprovalRequired: true,
 			}
+		case "member_link_mode":
+			modeBytes, _ := child.Content.([]byte)
+			mode := types.GroupMemberLinkMode(modeBytes)
+			evt.MemberLinkMode = &mode
 		case "suspended":
 			evt.Suspended = true
 		case "unsuspended":
@@ -1065,3 +1166,85 @@ func (cli *Client) SetGroupDescription(ctx context.Context, jid types.JID, descr
 	_, err := cli.sendGroupIQ(ctx, iqSet, jid, content)
 	return err
 }
+
+// SetGroupMemberLinkMode changes who can see and share the invite link of the group.
+func (cli *Client) SetGroupMemberLinkMode(ctx context.Context, jid types.JID, mode types.GroupMemberLinkMode) error {
+	if mode != types.GroupMemberLinkModeAdmin && mode != types.GroupMemberLinkModeAllMember {
+		return errors.New("invalid mode, must be 'admin_link' or 'all_member_link'")
+	}
+	_, err := cli.sendGroupIQ(ctx, iqSet, jid, waBinary.Node{
+		Tag:     "member_link_mode",
+		Content: []byte(mode),
+	})
+	return err
+}
+
+type mexParticipantLabelEnabledInput struct {
+	GroupID                 types.JID `json:"group_id"`
+	ParticipantLabelEnabled bool      `json:"participant_label_enabled"`
+}
+
+type mexParticipantLabelEnabledVars struct {
+	Input mexParticipantLabelEnabledInput `json:"input"`
+}
+
+type mexParticipantLabelInput struct {
+	GroupID          types.JID `json:"group_id"`
+	ParticipantLabel struct {
+		Label string `json:"label"`
+	} `json:"participant_label"`
+}
+
+type mexParticipantLabelVars struct {
+	Input mexParticipantLabelInput `json:"input"`
+}
+
+type respUpdateParticipantLabel struct {
+	Result *struct {
+		GroupID types.JID `json:"group_jid"`
+		Error   *struct {
+			ResponseCode string `json:"response_code"`
+		} `json:"error"`
+		ParticipantLabel *types.GroupParticipantLabel `json:"participant_label"`
+	} `json:"xwa2_group_update_participant_property"`
+}
+
+var (
+	mexSetParticipantLabelEnabled = RegisterMexOperation[mexParticipantLabelEnabledVars, respUpdateGroupProperty](
+		"GroupUpdateParticipantLabelEnabledMutation", MexQueryIDs{},
+	)
+	mexUpdateParticipantLabel = RegisterMexOperation[mexParticipantLabelVars, respUpdateParticipantLabel](
+		"UpdateGroupParticipantLabelMutation", MexQueryIDs{},
+	)
+)
+
+// SetGroupParticipantLabelEnabled changes whether participants can set labels for themselves in the group.
+//
+// The change is sent to other participants as an events.GroupPropertyChange.
+func (cli *Client) SetGroupParticipantLabelEnabled(ctx context.Context, jid types.JID, enabled bool) error {
+	_, err := SendMexOperation(ctx, cli, mexSetParticipantLabelEnabled, mexParticipantLabelEnabledVars{
+		Input: mexParticipantLabelEnabledInput{
+			GroupID:                 jid,
+			ParticipantLabelEnabled: enabled,
+		},
+	})
+	return err
+}
+
+// SetGroupParticipantLabel sets the label of the current user in the given group. An empty label removes it.
+//
+// The group must have participant labels enabled (see SetGroupParticipantLabelEnabled).
+// The change is sent to other participants as an events.GroupParticipantLabelChange.
+func (cli *Client) SetGroupParticipantLabel(ctx context.Context, jid types.JID, label string) (*types.GroupParticipantLabel, error) {
+	vars := mexParticipantLabelVars{Input: mexParticipantLabelInput{GroupID: jid}}
+	vars.Input.ParticipantLabel.Label = label
+	resp, err := SendMexOperation(ctx, cli, mexUpdateParticipantLabel, vars)
+	if err != nil {
+		return nil, err
+	} else if resp.Result == nil {
+		return nil, ErrGroupNotFound
+	} else if resp.Result.Error != nil && resp.Result.Error.ResponseCode != "" {
+		return nil, fmt.Errorf("failed to set participant label: %s", resp.Result.Error.ResponseCode)
+	}
+	return resp.Result.ParticipantLabel, nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// DefaultParticipantBatchSize is the number of participants changed per request in UpdateGroupParticipantsBulk
+// if BulkParticipantChangeOptions.BatchSize is not set.
+const DefaultParticipantBatchSize = 20
+
+// GroupParticipantErrorCode is an error code returned by the server for a single participant in a participant update.
+type GroupParticipantErrorCode int
+
+const (
+	GroupParticipantErrorNone GroupParticipantErrorCode = 0
+	// The current user isn't allowed to add the participant (e.g. because the current user isn't an admin).
+	GroupParticipantErrorNotAuthorized GroupParticipantErrorCode = 401
+	// The participant's privacy settings don't allow adding them to groups directly. They can be sent an invite instead.
+	GroupParticipantErrorPrivacy GroupParticipantErrorCode = 403
+	// The participant isn't on WhatsApp.
+	GroupParticipantErrorNotFound GroupParticipantErrorCode = 404
+	// The participant recently left the group and can't be re-added yet.
+	GroupParticipantErrorRecentlyLeft GroupParticipantErrorCode = 408
+	// The participant is already in the group (when adding) or isn't in the group (when removing).
+	GroupParticipantErrorConflict GroupParticipantErrorCode = 409
+	// The group is full.
+	GroupParticipantErrorGroupFull GroupParticipantErrorCode = 500
+)
+
+// BulkParticipantChangeOptions contains the optional parameters for UpdateGroupParticipantsBulk.
+type BulkParticipantChangeOptions struct {
+	// The maximum number of participants to change in a single request. Defaults to DefaultParticipantBatchSize.
+	BatchSize int
+	// If true, users who can't be added directly because of their privacy settings will be sent a group invite message.
+	// Invites are only sent if the server returned an add request for the user, otherwise InviteError is set in the result.
+	SendInvites bool
+	// The caption to include in the invite messages.
+	InviteCaption string
+}
+
+// BulkParticipantResult contains the result of changing a single participant in UpdateGroupParticipantsBulk.
+type BulkParticipantResult struct {
+	JID types.JID
+	// The participant info returned by the server. This is nil if the request for the batch failed.
+	Participant *types.GroupParticipant
+	ErrorCode   GroupParticipantErrorCode
+	// The error that caused the request for the whole batch to fail.
+	BatchError error
+
+	// Whether an invite message was sent to the user (only if SendInvites was set).
+	InviteSent  bool
+	InviteError error
+}
+
+// Success returns true if the participant was changed successfully.
+func (res *BulkParticipantResult) Success() bool {
+	return res.BatchError == nil && res.ErrorCode == GroupParticipantErrorNone
+}
+
+func findParticipantResult(participants []types.GroupParticipant, jid types.JID) *types.GroupParticipant {
+	for i := range participants {
+		if groupParticipantMatches(&participants[i], jid) {
+			return &participants[i]
+		}
+	}
+	return nil
+}
+
+// UpdateGroupParticipantsBulk can be used to add, remove, promote and/or demote a large number of users in a WhatsApp group.
+//
+// The participants are split into batches that are sent as separate requests, and the results are returned for each user
+// in the same order as the input. Failing batches don't stop the process, the error is returned in the results instead.
+// The returned error is only set if the context is canceled or the group info can't be fetched for sending invites.
+func (cli *Client) UpdateGroupParticipantsBulk(ctx context.Context, group types.JID, participants []types.JID, action ParticipantChange, opts BulkParticipantChangeOptions) ([]BulkParticipantResult, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultParticipantBatchSize
+	}
+	results := make([]BulkParticipantResult, len(participants))
+	for start := 0; start < len(participants); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		end := min(start+batchSize, len(participants))
+		batch := participants[start:end]
+		resp, err := cli.UpdateGroupParticipants(ctx, group, batch, action)
+		for i, jid := range batch {
+			result := &results[start+i]
+			result.JID = jid
+			if err != nil {
+				result.BatchError = err
+				continue
+			}
+			result.Participant = findParticipantResult(resp, jid)
+			if result.Participant == nil {
+				result.BatchError = fmt.Errorf("%w for %s", ErrMissingParticipantResult, jid)
+			} else {
+				result.ErrorCode = GroupParticipantErrorCode(result.Participant.Error)
+			}
+		}
+		if err != nil {
+			cli.Log.Warnf("Failed to %s batch of %d participants in %s: %v", action, len(batch), group, err)
+		}
+	}
+	if action == ParticipantChangeAdd && opts.SendInvites {
+		return results, cli.sendBulkAddInvites(ctx, group, results, opts.InviteCaption)
+	}
+	return results, nil
+}
+
+func (cli *Client) sendBulkAddInvites(ctx context.Context, group types.JID, results []BulkParticipantResult, caption string) error {
+	var groupInfo *types.GroupInfo
+	for i := range results {
+		result := &results[i]
+		if result.ErrorCode != GroupParticipantErrorPrivacy {
+			continue
+		} else if result.Participant.AddRequest == nil {
+			// Invite messages can only be accepted with a user-specific add request code
+			result.InviteError = fmt.Errorf("%w %s", ErrMissingAddRequest, result.JID)
+			continue
+		}
+		if groupInfo == nil {
+			var err error
+			groupInfo, err = cli.GetGroupInfo(ctx, group)
+			if err != nil {
+				return fmt.Errorf("failed to get group info for invite messages: %w", err)
+			}
+		}
+		msg := cli.BuildGroupInviteMessage(group, groupInfo.Name, *result.Participant.AddRequest, caption)
+		_, err := cli.SendMessage(ctx, result.JID, msg)
+		if err != nil {
+			result.InviteError = err
+		} else {
+			result.InviteSent = true
+		}
+	}
+	return nil
+}
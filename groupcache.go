@@ -0,0 +1,214 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// MaxStoredGroupInfoAge is how long group info in Store.Groups is used by GetGroupInfo when [Client.CacheFirstGroupInfo] is set.
+//
+// The stored info is updated based on group notifications, but notifications may be missed e.g. while the client is offline
+// for a long time, so the info is refetched from the server when it gets too old.
+const MaxStoredGroupInfoAge = 24 * time.Hour
+
+func (cli *Client) useGroupStore() bool {
+	return cli.CacheFirstGroupInfo && cli.Store.Groups != nil
+}
+
+func (cli *Client) getStoredGroupInfo(ctx context.Context, jid types.JID) (*types.GroupInfo, time.Time) {
+	if !cli.useGroupStore() {
+		return nil, time.Time{}
+	}
+	info, fetchedAt, err := cli.Store.Groups.GetGroup(ctx, jid)
+	if err != nil {
+		cli.Log.Warnf("Failed to get cached group info of %s: %v", jid, err)
+		return nil, time.Time{}
+	}
+	return info, fetchedAt
+}
+
+// getFreshStoredGroupInfo returns the stored info of the given group, unless it's older than MaxStoredGroupInfoAge.
+func (cli *Client) getFreshStoredGroupInfo(ctx context.Context, jid types.JID) *types.GroupInfo {
+	info, fetchedAt := cli.getStoredGroupInfo(ctx, jid)
+	if info == nil || time.Since(fetchedAt) > MaxStoredGroupInfoAge {
+		return nil
+	}
+	return info
+}
+
+func (cli *Client) storeGroupInfo(ctx context.Context, info *types.GroupInfo, fetchedAt time.Time) {
+	if !cli.useGroupStore() {
+		return
+	}
+	err := cli.Store.Groups.PutGroup(ctx, info, fetchedAt)
+	if err != nil {
+		cli.Log.Warnf("Failed to store group info of %s: %v", info.JID, err)
+	}
+}
+
+func (cli *Client) deleteStoredGroupInfo(ctx context.Context, jid types.JID) {
+	if !cli.useGroupStore() {
+		return
+	}
+	err := cli.Store.Groups.DeleteGroup(ctx, jid)
+	if err != nil {
+		cli.Log.Warnf("Failed to delete cached group info of %s: %v", jid, err)
+	}
+}
+
+func (cli *Client) isOwnJID(jid types.JID) bool {
+	jid = jid.ToNonAD()
+	return jid == cli.getOwnID().ToNonAD() || jid == cli.getOwnLID().ToNonAD()
+}
+
+func newGroupParticipantFromChange(jid types.JID, lidPairs []store.LIDMapping) types.GroupParticipant {
+	participant := types.GroupParticipant{JID: jid}
+	if jid.Server == types.HiddenUserServer {
+		participant.LID = jid
+	} else if jid.Server == types.DefaultUserServer {
+		participant.PhoneNumber = jid
+	}
+	for _, pair := range lidPairs {
+		if pair.LID == jid {
+			participant.PhoneNumber = pair.PN
+		} else if pair.PN == jid {
+			participant.LID = pair.LID
+		}
+	}
+	return participant
+}
+
+func groupParticipantMatches(participant *types.GroupParticipant, jid types.JID) bool {
+	return participant.JID == jid || participant.LID == jid || participant.PhoneNumber == jid
+}
+
+func setGroupParticipantsAdmin(info *types.GroupInfo, jids []types.JID, admin bool) {
+	for i := range info.Participants {
+		participant := &info.Participants[i]
+		if slices.ContainsFunc(jids, func(jid types.JID) bool { return groupParticipantMatches(participant, jid) }) {
+			participant.IsAdmin = admin
+			if !admin {
+				participant.IsSuperAdmin = false
+			}
+		}
+	}
+}
+
+// applyGroupChange applies the given group change to the cached group info.
+// It returns false if the change can't be applied and the cached info should be discarded instead.
+func (cli *Client) applyGroupChange(info *types.GroupInfo, evt *events.GroupInfo, lidPairs []store.LIDMapping) bool {
+	if evt.Delete != nil || slices.ContainsFunc(evt.Leave, cli.isOwnJID) {
+		return false
+	} else if evt.PrevParticipantVersionID != "" && evt.PrevParticipantVersionID != info.ParticipantVersionID {
+		// The cached participant list is outdated (e.g. some notifications were missed), so it must be refetched.
+		return false
+	}
+	if evt.Name != nil {
+		info.GroupName = *evt.Name
+	}
+	if evt.Topic != nil {
+		info.GroupTopic = *evt.Topic
+	}
+	if evt.Locked != nil {
+		info.GroupLocked = *evt.Locked
+	}
+	if evt.Announce != nil {
+		info.GroupAnnounce = *evt.Announce
+	}
+	if evt.Ephemeral != nil {
+		info.GroupEphemeral = *evt.Ephemeral
+	}
+	if evt.MembershipApprovalMode != nil {
+		info.GroupMembershipApprovalMode = *evt.MembershipApprovalMode
+	}
+	if evt.MemberLinkMode != nil {
+		info.MemberLinkMode = *evt.MemberLinkMode
+	}
+	if evt.ParticipantLabelEnabled != nil {
+		info.IsParticipantLabelEnabled = *evt.ParticipantLabelEnabled
+	}
+	if evt.SafetyCheck != nil {
+		info.IsSafetyCheckEnabled = *evt.SafetyCheck
+	}
+	if evt.Hidden != nil {
+		info.IsHidden = *evt.Hidden
+	}
+	if evt.Suspended {
+		info.Suspended = true
+	} else if evt.Unsuspended {
+		info.Suspended = false
+	}
+	for _, jid := range evt.Join {
+		if !slices.ContainsFunc(info.Participants, func(participant types.GroupParticipant) bool {
+			return groupParticipantMatches(&participant, jid)
+		}) {
+			info.Participants = append(info.Participants, newGroupParticipantFromChange(jid, lidPairs))
+		}
+	}
+	for _, jid := range evt.Leave {
+		info.Participants = slices.DeleteFunc(info.Participants, func(participant types.GroupParticipant) bool {
+			return groupParticipantMatches(&participant, jid)
+		})
+	}
+	setGroupParticipantsAdmin(info, evt.Promote, true)
+	setGroupParticipantsAdmin(info, evt.Demote, false)
+	if len(evt.Join) > 0 || len(evt.Leave) > 0 {
+		info.ParticipantCount = len(info.Participants)
+	}
+	if evt.ParticipantVersionID != "" {
+		info.ParticipantVersionID = evt.ParticipantVersionID
+	}
+	return true
+}
+
+func (cli *Client) updateStoredGroupInfo(ctx context.Context, evt any, lidPairs []store.LIDMapping) {
+	switch typedEvt := evt.(type) {
+	case *events.JoinedGroup:
+		cli.storeGroupInfo(ctx, &typedEvt.GroupInfo, time.Now())
+	case *events.GroupInfo:
+		info, fetchedAt := cli.getStoredGroupInfo(ctx, typedEvt.JID)
+		if info == nil {
+			return
+		} else if cli.applyGroupChange(info, typedEvt, lidPairs) {
+			cli.storeGroupInfo(ctx, info, fetchedAt)
+		} else {
+			cli.deleteStoredGroupInfo(ctx, typedEvt.JID)
+		}
+	}
+}
+
+func (cli *Client) updateStoredParticipantLabel(ctx context.Context, evt *events.GroupParticipantLabelChange) {
+	if evt.UpdatedBy == nil || evt.Update.GroupID.IsEmpty() {
+		return
+	}
+	info, fetchedAt := cli.getStoredGroupInfo(ctx, evt.Update.GroupID)
+	if info == nil {
+		return
+	}
+	for i := range info.Participants {
+		participant := &info.Participants[i]
+		if groupParticipantMatches(participant, evt.UpdatedBy.JID) {
+			participant.Label = ""
+			participant.LabelSetAt = evt.UpdateTime.Time
+			if evt.Update.Label != nil {
+				participant.Label = evt.Update.Label.Label
+				if !evt.Update.Label.LastModified.IsZero() {
+					participant.LabelSetAt = evt.Update.Label.LastModified.Time
+				}
+			}
+			cli.storeGroupInfo(ctx, info, fetchedAt)
+			return
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/proto/waGroupHistory"
+	"go.mau.fi/whatsmeow/proto/waWeb"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// GroupHistoryMimetype is the mimetype used for group history bundles.
+const GroupHistoryMimetype = "application/x-protobuf"
+
+// BuildGroupHistoryBundle encodes and uploads the given messages as a group history bundle
+// and returns a message that can be sent to the group.
+//
+// The receivers are the users who should import the history, usually the participants who were just added to the group.
+// The messages should be in the same format as in history syncs, e.g. the SourceWebMsg field of events.Message.
+func (cli *Client) BuildGroupHistoryBundle(ctx context.Context, receivers []types.JID, messages []*waWeb.WebMessageInfo) (*waE2E.Message, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	rawData, err := proto.Marshal(&waGroupHistory.GroupHistory{Messages: messages})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal group history: %w", err)
+	}
+	var buf bytes.Buffer
+	writer := zlib.NewWriter(&buf)
+	if _, err = writer.Write(rawData); err != nil {
+		return nil, fmt.Errorf("failed to compress group history: %w", err)
+	} else if err = writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress group history: %w", err)
+	}
+	uploaded, err := cli.Upload(ctx, buf.Bytes(), MediaHistory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload group history: %w", err)
+	}
+	receiverStrings := make([]string, len(receivers))
+	for i, receiver := range receivers {
+		receiverStrings[i] = receiver.ToNonAD().String()
+	}
+	var oldestTimestamp uint64
+	for _, msg := range messages {
+		if ts := msg.GetMessageTimestamp(); ts != 0 && (oldestTimestamp == 0 || ts < oldestTimestamp) {
+			oldestTimestamp = ts
+		}
+	}
+	return &waE2E.Message{
+		MessageHistoryBundle: &waE2E.MessageHistoryBundle{
+			Mimetype:          proto.String(GroupHistoryMimetype),
+			FileSHA256:        uploaded.FileSHA256,
+			MediaKey:          uploaded.MediaKey,
+			FileEncSHA256:     uploaded.FileEncSHA256,
+			DirectPath:        proto.String(uploaded.DirectPath),
+			MediaKeyTimestamp: proto.Int64(time.Now().Unix()),
+			MessageHistoryMetadata: &waE2E.MessageHistoryMetadata{
+				HistoryReceivers:       receiverStrings,
+				OldestMessageTimestamp: proto.Int64(int64(oldestTimestamp)),
+				MessageCount:           proto.Int64(int64(len(messages))),
+			},
+		},
+	}, nil
+}
+
+// SendGroupHistory shares the given messages with the given receivers in a group.
+func (cli *Client) SendGroupHistory(ctx context.Context, group types.JID, receivers []types.JID, messages []*waWeb.WebMessageInfo) (SendResponse, error) {
+	msg, err := cli.BuildGroupHistoryBundle(ctx, receivers, messages)
+	if err != nil {
+		return SendResponse{}, err
+	}
+	return cli.SendMessage(ctx, group, msg)
+}
+
+// AddGroupParticipantsWithHistory adds the given users to a group using UpdateGroupParticipants,
+// and then shares the given messages with the users who were added successfully.
+//
+// If adding the participants succeeds but sending the history fails, the participant list is returned along with the error.
+func (cli *Client) AddGroupParticipantsWithHistory(ctx context.Context, group types.JID, participants []types.JID, history []*waWeb.WebMessageInfo) ([]types.GroupParticipant, error) {
+	added, err := cli.UpdateGroupParticipants(ctx, group, participants, ParticipantChangeAdd)
+	if err != nil || len(history) == 0 {
+		return added, err
+	}
+	receivers := make([]types.JID, 0, len(added))
+	for _, participant := range added {
+		if participant.Error == 0 {
+			receivers = append(receivers, participant.JID)
+		}
+	}
+	if len(receivers) == 0 {
+		return added, nil
+	}
+	_, err = cli.SendGroupHistory(ctx, group, receivers, history)
+	if err != nil {
+		return added, fmt.Errorf("failed to send group history: %w", err)
+	}
+	return added, nil
+}
+
+// DownloadGroupHistory downloads and parses the messages in the given group history bundle.
+//
+// You only need to call this manually if you set [Client.ManualHistorySyncDownload] to true.
+// By default, whatsmeow will call this automatically and dispatch an [events.GroupHistory] with the parsed messages.
+func (cli *Client) DownloadGroupHistory(ctx context.Context, group types.JID, bundle *waE2E.MessageHistoryBundle) ([]*events.Message, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	data, err := cli.Download(ctx, bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download: %w", err)
+	}
+	// GroupHistoryWithMessageBytes is wire-compatible with GroupHistory, so either variant can be parsed this way.
+	var history waGroupHistory.GroupHistory
+	if reader, err := zlib.NewReader(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to prepare to decompress: %w", err)
+	} else if rawData, err := io.ReadAll(reader); err != nil {
+		return nil, fmt.Errorf("failed to decompress: %w", err)
+	} else if err = proto.Unmarshal(rawData, &history); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal: %w", err)
+	}
+	messages := make([]*events.Message, 0, len(history.GetMessages())+len(history.GetCommentMessages()))
+	for _, webMsg := range slices.Concat(history.GetMessages(), history.GetCommentMessages()) {
+		evt, err := cli.ParseWebMessage(group, webMsg)
+		if err != nil {
+			cli.Log.Warnf("Failed to parse message %s in group history bundle: %v", webMsg.GetKey().GetID(), err)
+			continue
+		}
+		messages = append(messages, evt)
+	}
+	return messages, nil
+}
+
+func (cli *Client) isGroupHistoryReceiver(bundle *waE2E.MessageHistoryBundle) bool {
+	receivers := bundle.GetMessageHistoryMetadata().GetHistoryReceivers()
+	if len(receivers) == 0 {
+		return true
+	}
+	ownID := cli.getOwnID().ToNonAD().String()
+	ownLID := cli.getOwnLID().ToNonAD().String()
+	return slices.Contains(receivers, ownID) || slices.Contains(receivers, ownLID)
+}
+
+func (cli *Client) handleGroupHistoryBundle(ctx context.Context, info *types.MessageInfo, bundle *waE2E.MessageHistoryBundle) {
+	if !info.IsGroup || info.IsFromMe || !cli.isGroupHistoryReceiver(bundle) {
+		return
+	}
+	messages, err := cli.DownloadGroupHistory(ctx, info.Chat, bundle)
+	if err != nil {
+		cli.Log.Errorf("Failed to download group history bundle %s in %s: %v", info.ID, info.Chat, err)
+		return
+	}
+	cli.Log.Debugf("Received group history bundle %s in %s with %d messages", info.ID, info.Chat, len(messages))
+	cli.dispatchEvent(&events.GroupHistory{
+		Info:     *info,
+		Bundle:   bundle,
+		Messages: messages,
+	})
+}
@@ -9,6 +9,7 @@ package whatsmeow
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,46 +25,192 @@ import (
 const NoiseHandshakeResponseTimeout = 20 * time.Second
 const WACertIssuerSerial = 0
 
+// errResumeDecryptFailed is returned by finishResumeHandshake when the server's Noise_IK response can't be decrypted,
+// which means the cached server static key can't be trusted anymore.
+var errResumeDecryptFailed = errors.New("failed to decrypt resume handshake payload")
+
 var WACertPubKey = [...]byte{0x14, 0x23, 0x75, 0x57, 0x4d, 0xa, 0x58, 0x71, 0x66, 0xaa, 0xe7, 0x1e, 0xbe, 0x51, 0x64, 0x37, 0xc4, 0xa2, 0x8b, 0x73, 0xe3, 0x69, 0x5c, 0x6c, 0xe1, 0xf7, 0xf9, 0x54, 0x5d, 0xa8, 0xee, 0x6b}
 
-// doHandshake implements the Noise_XX_25519_AESGCM_SHA256 handshake for the WhatsApp web API.
+// doHandshake does the noise handshake for the WhatsApp web API.
+//
+// If the server's static key is cached from a previous connection, a Noise_IK_25519_AESGCM_SHA256 handshake is
+// attempted first, which saves a round trip and skips verifying the certificate chain. If the server rejects it
+// (e.g. because its static key has changed), the handshake continues with Noise_XXfallback. Otherwise, a full
+// Noise_XX_25519_AESGCM_SHA256 handshake is done.
 func (cli *Client) doHandshake(ctx context.Context, fs *socket.FrameSocket, ephemeralKP keys.KeyPair) error {
+	// The header is only sent with the first frame, but the fallback handshake needs it again for the prologue
+	header := fs.Header
+	var nh *socket.NoiseHandshake
+	var serverHello *waWa6.HandshakeMessage_ServerHello
+	if serverStatic := cli.Store.ServerStaticKey; len(serverStatic) == 32 {
+		var err error
+		nh, serverHello, err = cli.sendResumeHandshake(fs, header, ephemeralKP, [32]byte(serverStatic))
+		if err == nil && len(serverHello.GetStatic()) == 0 {
+			err = cli.finishResumeHandshake(ctx, fs, nh, ephemeralKP, serverHello)
+			if err == nil {
+				return nil
+			}
+		}
+		if errors.Is(err, errResumeDecryptFailed) {
+			// The cached key may be outdated, so do a full handshake on the next attempt
+			cli.clearServerStaticKey(ctx)
+			return err
+		} else if err != nil {
+			return err
+		}
+		cli.Log.Debugf("Server rejected Noise_IK handshake, falling back to Noise_XX")
+		nh = socket.NewNoiseHandshake()
+		nh.Start(socket.NoiseXXFallbackPattern, header)
+		nh.Authenticate(ephemeralKP.Pub[:])
+	} else {
+		nh = socket.NewNoiseHandshake()
+		nh.Start(socket.NoiseStartPattern, header)
+		nh.Authenticate(ephemeralKP.Pub[:])
+		err := sendHandshakeMessage(fs, &waWa6.HandshakeMessage{
+			ClientHello: &waWa6.HandshakeMessage_ClientHello{
+				Ephemeral: ephemeralKP.Pub[:],
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send handshake message: %w", err)
+		}
+		serverHello, err = readServerHello(fs)
+		if err != nil {
+			return err
+		}
+	}
+	return cli.finishFullHandshake(ctx, fs, nh, ephemeralKP, serverHello)
+}
+
+func sendHandshakeMessage(fs *socket.FrameSocket, msg *waWa6.HandshakeMessage) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal handshake message: %w", err)
+	}
+	return fs.SendFrame(data)
+}
+
+func readServerHello(fs *socket.FrameSocket) (*waWa6.HandshakeMessage_ServerHello, error) {
+	var resp []byte
+	select {
+	case resp = <-fs.Frames:
+	case <-time.After(NoiseHandshakeResponseTimeout):
+		return nil, fmt.Errorf("timed out waiting for handshake response")
+	}
+	var handshakeResponse waWa6.HandshakeMessage
+	err := proto.Unmarshal(resp, &handshakeResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal handshake response: %w", err)
+	} else if len(handshakeResponse.GetServerHello().GetEphemeral()) != 32 {
+		return nil, fmt.Errorf("missing server ephemeral key in handshake response")
+	}
+	return handshakeResponse.GetServerHello(), nil
+}
+
+func (cli *Client) getClientPayloadBytes() ([]byte, error) {
+	var clientPayload *waWa6.ClientPayload
+	if cli.GetClientPayload != nil {
+		clientPayload = cli.GetClientPayload()
+	} else {
+		clientPayload = cli.Store.GetClientPayload()
+	}
+	data, err := proto.Marshal(clientPayload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal client payload: %w", err)
+	}
+	return data, nil
+}
+
+// sendResumeHandshake sends the first message of the Noise_IK handshake (-> e, es, s, ss) and reads the response.
+func (cli *Client) sendResumeHandshake(
+	fs *socket.FrameSocket,
+	header []byte,
+	ephemeralKP keys.KeyPair,
+	serverStatic [32]byte,
+) (*socket.NoiseHandshake, *waWa6.HandshakeMessage_ServerHello, error) {
 	nh := socket.NewNoiseHandshake()
-	nh.Start(socket.NoiseStartPattern, fs.Header)
+	nh.Start(socket.NoiseIKStartPattern, header)
+	nh.Authenticate(serverStatic[:])
 	nh.Authenticate(ephemeralKP.Pub[:])
-	data, err := proto.Marshal(&waWa6.HandshakeMessage{
+	err := nh.MixSharedSecretIntoKey(*ephemeralKP.Priv, serverStatic)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to mix server static key in: %w", err)
+	}
+	encryptedPubkey := nh.Encrypt(cli.Store.NoiseKey.Pub[:])
+	err = nh.MixSharedSecretIntoKey(*cli.Store.NoiseKey.Priv, serverStatic)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to mix noise private key in: %w", err)
+	}
+	clientPayload, err := cli.getClientPayloadBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	err = sendHandshakeMessage(fs, &waWa6.HandshakeMessage{
 		ClientHello: &waWa6.HandshakeMessage_ClientHello{
 			Ephemeral: ephemeralKP.Pub[:],
+			Static:    encryptedPubkey,
+			Payload:   nh.Encrypt(clientPayload),
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal handshake message: %w", err)
+		return nil, nil, fmt.Errorf("failed to send resume handshake message: %w", err)
 	}
-	err = fs.SendFrame(data)
+	serverHello, err := readServerHello(fs)
 	if err != nil {
-		return fmt.Errorf("failed to send handshake message: %w", err)
+		return nil, nil, err
 	}
-	var resp []byte
-	select {
-	case resp = <-fs.Frames:
-	case <-time.After(NoiseHandshakeResponseTimeout):
-		return fmt.Errorf("timed out waiting for handshake response")
+	return nh, serverHello, nil
+}
+
+// finishResumeHandshake processes the server's response to a successful Noise_IK handshake (<- e, ee, se).
+func (cli *Client) finishResumeHandshake(
+	ctx context.Context,
+	fs *socket.FrameSocket,
+	nh *socket.NoiseHandshake,
+	ephemeralKP keys.KeyPair,
+	serverHello *waWa6.HandshakeMessage_ServerHello,
+) error {
+	serverEphemeral := [32]byte(serverHello.GetEphemeral())
+	nh.Authenticate(serverEphemeral[:])
+	err := nh.MixSharedSecretIntoKey(*ephemeralKP.Priv, serverEphemeral)
+	if err != nil {
+		return fmt.Errorf("failed to mix server ephemeral key in: %w", err)
 	}
-	var handshakeResponse waWa6.HandshakeMessage
-	err = proto.Unmarshal(resp, &handshakeResponse)
+	err = nh.MixSharedSecretIntoKey(*cli.Store.NoiseKey.Priv, serverEphemeral)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal handshake response: %w", err)
+		return fmt.Errorf("failed to mix noise private key in: %w", err)
+	}
+	_, err = nh.Decrypt(serverHello.GetPayload())
+	if err != nil {
+		return fmt.Errorf("%w: %w", errResumeDecryptFailed, err)
 	}
-	serverEphemeral := handshakeResponse.GetServerHello().GetEphemeral()
-	serverStaticCiphertext := handshakeResponse.GetServerHello().GetStatic()
-	certificateCiphertext := handshakeResponse.GetServerHello().GetPayload()
-	if len(serverEphemeral) != 32 || serverStaticCiphertext == nil || certificateCiphertext == nil {
+	ns, err := nh.Finish(ctx, fs, cli.handleFrame, cli.onDisconnect)
+	if err != nil {
+		return fmt.Errorf("failed to create noise socket: %w", err)
+	}
+	cli.socket = ns
+	return nil
+}
+
+// finishFullHandshake processes the server hello of a Noise_XX or Noise_XXfallback handshake (<- e, ee, s, es),
+// verifies the server certificate and sends the final message (-> s, se).
+func (cli *Client) finishFullHandshake(
+	ctx context.Context,
+	fs *socket.FrameSocket,
+	nh *socket.NoiseHandshake,
+	ephemeralKP keys.KeyPair,
+	serverHello *waWa6.HandshakeMessage_ServerHello,
+) error {
+	serverEphemeral := serverHello.GetEphemeral()
+	serverStaticCiphertext := serverHello.GetStatic()
+	certificateCiphertext := serverHello.GetPayload()
+	if serverStaticCiphertext == nil || certificateCiphertext == nil {
 		return fmt.Errorf("missing parts of handshake response")
 	}
 	serverEphemeralArr := *(*[32]byte)(serverEphemeral)
 
 	nh.Authenticate(serverEphemeral)
-	err = nh.MixSharedSecretIntoKey(*ephemeralKP.Priv, serverEphemeralArr)
+	err := nh.MixSharedSecretIntoKey(*ephemeralKP.Priv, serverEphemeralArr)
 	if err != nil {
 		return fmt.Errorf("failed to mix server ephemeral key in: %w", err)
 	}
@@ -92,28 +239,17 @@ func (cli *Client) doHandshake(ctx context.Context, fs *socket.FrameSocket, ephe
 		return fmt.Errorf("failed to mix noise private key in: %w", err)
 	}
 
-	var clientPayload *waWa6.ClientPayload
-	if cli.GetClientPayload != nil {
-		clientPayload = cli.GetClientPayload()
-	} else {
-		clientPayload = cli.Store.GetClientPayload()
-	}
-
-	clientFinishPayloadBytes, err := proto.Marshal(clientPayload)
+	clientFinishPayloadBytes, err := cli.getClientPayloadBytes()
 	if err != nil {
-		return fmt.Errorf("failed to marshal client finish payload: %w", err)
+		return err
 	}
 	encryptedClientFinishPayload := nh.Encrypt(clientFinishPayloadBytes)
-	data, err = proto.Marshal(&waWa6.HandshakeMessage{
+	err = sendHandshakeMessage(fs, &waWa6.HandshakeMessage{
 		ClientFinish: &waWa6.HandshakeMessage_ClientFinish{
 			Static:  encryptedPubkey,
 			Payload: encryptedClientFinishPayload,
 		},
 	})
-	if err != nil {
-		return fmt.Errorf("failed to marshal handshake finish message: %w", err)
-	}
-	err = fs.SendFrame(data)
 	if err != nil {
 		return fmt.Errorf("failed to send handshake finish message: %w", err)
 	}
@@ -124,10 +260,40 @@ func (cli *Client) doHandshake(ctx context.Context, fs *socket.FrameSocket, ephe
 	}
 
 	cli.socket = ns
+	cli.updateServerStaticKey(ctx, staticDecrypted)
 
 	return nil
 }
 
+// updateServerStaticKey caches the verified server static key for resuming future connections with Noise_IK.
+func (cli *Client) updateServerStaticKey(ctx context.Context, serverStatic []byte) {
+	if bytes.Equal(cli.Store.ServerStaticKey, serverStatic) {
+		return
+	}
+	cli.Store.ServerStaticKey = bytes.Clone(serverStatic)
+	if cli.Store.ID != nil {
+		err := cli.Store.Save(ctx)
+		if err != nil {
+			cli.Log.Warnf("Failed to save device store after updating server static key: %v", err)
+		}
+	}
+}
+
+// clearServerStaticKey removes the cached server static key, so that the next connection does a full handshake.
+func (cli *Client) clearServerStaticKey(ctx context.Context) {
+	if cli.Store.ServerStaticKey == nil {
+		return
+	}
+	cli.Log.Debugf("Clearing cached server static key after failed Noise_IK handshake")
+	cli.Store.ServerStaticKey = nil
+	if cli.Store.ID != nil {
+		err := cli.Store.Save(ctx)
+		if err != nil {
+			cli.Log.Warnf("Failed to save device store after clearing server static key: %v", err)
+		}
+	}
+}
+
 func verifyServerCert(certDecrypted, staticDecrypted []byte) error {
 	var certChain waCert.CertChain
 	err := proto.Unmarshal(certDecrypted, &certChain)
@@ -0,0 +1,366 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"go.mau.fi/libsignal/ecc"
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waCert"
+	"go.mau.fi/whatsmeow/proto/waWa6"
+	"go.mau.fi/whatsmeow/socket"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/util/keys"
+	waLog "go.mau.fi/whatsmeow/util/log"
+)
+
+const testClientUsername = 1234567890
+
+type handshakeResult struct {
+	pattern string
+	err     error
+}
+
+// testHandshakePeer is a minimal server side implementation of the WhatsApp noise handshake.
+type testHandshakePeer struct {
+	staticKey *keys.KeyPair
+	certChain []byte
+	// If true, successful Noise_IK handshakes are answered with a corrupted payload.
+	corruptResume bool
+	// If true, Noise_IK handshakes are answered with a server hello that's missing the ephemeral key.
+	brokenResume bool
+
+	results chan handshakeResult
+}
+
+func signTestCert(issuer *keys.KeyPair, details *waCert.CertChain_NoiseCertificate_Details) *waCert.CertChain_NoiseCertificate {
+	detailsBytes, _ := proto.Marshal(details)
+	signature := ecc.CalculateSignature(ecc.NewDjbECPrivateKey(*issuer.Priv), detailsBytes)
+	return &waCert.CertChain_NoiseCertificate{Details: detailsBytes, Signature: signature[:]}
+}
+
+// newTestHandshakePeer creates a peer with a new static key and a certificate chain signed by the given root key.
+func newTestHandshakePeer(root *keys.KeyPair) *testHandshakePeer {
+	staticKey := keys.NewKeyPair()
+	intermediate := keys.NewKeyPair()
+	certChain, _ := proto.Marshal(&waCert.CertChain{
+		Intermediate: signTestCert(root, &waCert.CertChain_NoiseCertificate_Details{
+			Serial:       proto.Uint32(1),
+			IssuerSerial: proto.Uint32(WACertIssuerSerial),
+			Key:          intermediate.Pub[:],
+		}),
+		Leaf: signTestCert(intermediate, &waCert.CertChain_NoiseCertificate_Details{
+			Serial:       proto.Uint32(2),
+			IssuerSerial: proto.Uint32(1),
+			Key:          staticKey.Pub[:],
+		}),
+	})
+	return &testHandshakePeer{
+		staticKey: staticKey,
+		certChain: certChain,
+		results:   make(chan handshakeResult, 1),
+	}
+}
+
+func (peer *testHandshakePeer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{InsecureSkipVerify: true})
+	if err != nil {
+		peer.results <- handshakeResult{err: err}
+		return
+	}
+	defer conn.CloseNow()
+	pattern, err := peer.handshake(r.Context(), conn)
+	peer.results <- handshakeResult{pattern: pattern, err: err}
+}
+
+func readTestFrame(ctx context.Context, conn *websocket.Conn, header []byte) ([]byte, error) {
+	_, data, err := conn.Read(ctx)
+	if err != nil {
+		return nil, err
+	} else if !bytes.HasPrefix(data, header) {
+		return nil, fmt.Errorf("missing header in frame")
+	}
+	data = data[len(header):]
+	if len(data) < socket.FrameLengthSize {
+		return nil, fmt.Errorf("frame too short")
+	}
+	length := int(data[0])<<16 | int(data[1])<<8 | int(data[2])
+	if len(data) != socket.FrameLengthSize+length {
+		return nil, fmt.Errorf("unexpected frame length %d (expected %d)", len(data)-socket.FrameLengthSize, length)
+	}
+	return data[socket.FrameLengthSize:], nil
+}
+
+func writeTestHandshakeMessage(ctx context.Context, conn *websocket.Conn, msg *waWa6.HandshakeMessage) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	frame := append([]byte{byte(len(data) >> 16), byte(len(data) >> 8), byte(len(data))}, data...)
+	return conn.Write(ctx, websocket.MessageBinary, frame)
+}
+
+func checkTestClientPayload(data []byte, err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to decrypt client payload: %w", err)
+	}
+	var payload waWa6.ClientPayload
+	if err = proto.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal client payload: %w", err)
+	} else if payload.GetUsername() != testClientUsername {
+		return fmt.Errorf("unexpected username %d in client payload", payload.GetUsername())
+	}
+	return nil
+}
+
+func (peer *testHandshakePeer) handshake(ctx context.Context, conn *websocket.Conn) (string, error) {
+	data, err := readTestFrame(ctx, conn, socket.WAConnHeader)
+	if err != nil {
+		return "", err
+	}
+	var clientHello waWa6.HandshakeMessage
+	if err = proto.Unmarshal(data, &clientHello); err != nil {
+		return "", err
+	}
+	clientEphemeral := [32]byte(clientHello.GetClientHello().GetEphemeral())
+	serverEphemeral := keys.NewKeyPair()
+
+	var nh *socket.NoiseHandshake
+	pattern := "XX"
+	if clientHello.GetClientHello().GetStatic() != nil {
+		nh = socket.NewNoiseHandshake()
+		nh.Start(socket.NoiseIKStartPattern, socket.WAConnHeader)
+		nh.Authenticate(peer.staticKey.Pub[:])
+		nh.Authenticate(clientEphemeral[:])
+		_ = nh.MixSharedSecretIntoKey(*peer.staticKey.Priv, clientEphemeral)
+		clientStatic, err := nh.Decrypt(clientHello.GetClientHello().GetStatic())
+		if err == nil && peer.brokenResume {
+			return "IK", writeTestHandshakeMessage(ctx, conn, &waWa6.HandshakeMessage{
+				ServerHello: &waWa6.HandshakeMessage_ServerHello{},
+			})
+		} else if err == nil {
+			// The client used the correct static key, so the resumption can be accepted
+			_ = nh.MixSharedSecretIntoKey(*peer.staticKey.Priv, [32]byte(clientStatic))
+			if err = checkTestClientPayload(nh.Decrypt(clientHello.GetClientHello().GetPayload())); err != nil {
+				return "IK", err
+			}
+			nh.Authenticate(serverEphemeral.Pub[:])
+			_ = nh.MixSharedSecretIntoKey(*serverEphemeral.Priv, clientEphemeral)
+			_ = nh.MixSharedSecretIntoKey(*serverEphemeral.Priv, [32]byte(clientStatic))
+			payload := nh.Encrypt(nil)
+			if peer.corruptResume {
+				payload[0] ^= 1
+			}
+			err = writeTestHandshakeMessage(ctx, conn, &waWa6.HandshakeMessage{
+				ServerHello: &waWa6.HandshakeMessage_ServerHello{
+					Ephemeral: serverEphemeral.Pub[:],
+					Payload:   payload,
+				},
+			})
+			if err != nil {
+				return "IK", err
+			}
+			return "IK", peer.checkTransport(ctx, conn, nh)
+		}
+		nh = socket.NewNoiseHandshake()
+		nh.Start(socket.NoiseXXFallbackPattern, socket.WAConnHeader)
+		pattern = "XXfallback"
+	} else {
+		nh = socket.NewNoiseHandshake()
+		nh.Start(socket.NoiseStartPattern, socket.WAConnHeader)
+	}
+	nh.Authenticate(clientEphemeral[:])
+	nh.Authenticate(serverEphemeral.Pub[:])
+	_ = nh.MixSharedSecretIntoKey(*serverEphemeral.Priv, clientEphemeral)
+	encryptedStatic := nh.Encrypt(peer.staticKey.Pub[:])
+	_ = nh.MixSharedSecretIntoKey(*peer.staticKey.Priv, clientEphemeral)
+	encryptedCert := nh.Encrypt(peer.certChain)
+	err = writeTestHandshakeMessage(ctx, conn, &waWa6.HandshakeMessage{
+		ServerHello: &waWa6.HandshakeMessage_ServerHello{
+			Ephemeral: serverEphemeral.Pub[:],
+			Static:    encryptedStatic,
+			Payload:   encryptedCert,
+		},
+	})
+	if err != nil {
+		return pattern, err
+	}
+
+	data, err = readTestFrame(ctx, conn, nil)
+	if err != nil {
+		return pattern, err
+	}
+	var clientFinish waWa6.HandshakeMessage
+	if err = proto.Unmarshal(data, &clientFinish); err != nil {
+		return pattern, err
+	}
+	clientStatic, err := nh.Decrypt(clientFinish.GetClientFinish().GetStatic())
+	if err != nil {
+		return pattern, fmt.Errorf("failed to decrypt client static: %w", err)
+	}
+	_ = nh.MixSharedSecretIntoKey(*serverEphemeral.Priv, [32]byte(clientStatic))
+	if err = checkTestClientPayload(nh.Decrypt(clientFinish.GetClientFinish().GetPayload())); err != nil {
+		return pattern, err
+	}
+	return pattern, peer.checkTransport(ctx, conn, nh)
+}
+
+// checkTransport checks that the first frame sent by the client can be decrypted with the final keys.
+func (peer *testHandshakePeer) checkTransport(ctx context.Context, conn *websocket.Conn, nh *socket.NoiseHandshake) error {
+	var clientWriteKey cipher.AEAD
+	clientWriteKey, _, err := nh.Split()
+	if err != nil {
+		return err
+	}
+	data, err := readTestFrame(ctx, conn, nil)
+	if err != nil {
+		return err
+	}
+	plaintext, err := clientWriteKey.Open(nil, make([]byte, 12), data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt transport frame: %w", err)
+	} else if string(plaintext) != "ping" {
+		return fmt.Errorf("unexpected transport frame %q", plaintext)
+	}
+	return nil
+}
+
+func newTestHandshakeEnv(t *testing.T) (*keys.KeyPair, *Client) {
+	root := keys.NewKeyPair()
+	origCertPubKey := WACertPubKey
+	WACertPubKey = *root.Pub
+	t.Cleanup(func() {
+		WACertPubKey = origCertPubKey
+	})
+	cli := NewClient(&store.Device{Log: waLog.Noop, NoiseKey: keys.NewKeyPair()}, nil)
+	cli.GetClientPayload = func() *waWa6.ClientPayload {
+		return &waWa6.ClientPayload{Username: proto.Uint64(testClientUsername)}
+	}
+	return root, cli
+}
+
+func connectToTestPeer(t *testing.T, cli *Client, peer *testHandshakePeer) (string, error) {
+	server := httptest.NewServer(peer)
+	defer server.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	fs := socket.NewFrameSocket(cli.Log, server.Client())
+	fs.URL = "ws" + strings.TrimPrefix(server.URL, "http")
+	err := fs.Connect(ctx)
+	if err != nil {
+		t.Fatalf("Failed to connect to test peer: %v", err)
+	}
+	cli.socketLock.Lock()
+	err = cli.doHandshake(ctx, fs, *keys.NewKeyPair())
+	if err == nil {
+		err = cli.socket.SendFrame(ctx, []byte("ping"))
+	}
+	cli.socketLock.Unlock()
+	if err != nil {
+		fs.Close(0)
+		return "", err
+	}
+	defer cli.Disconnect()
+	select {
+	case res := <-peer.results:
+		return res.pattern, res.err
+	case <-ctx.Done():
+		t.Fatalf("Timed out waiting for test peer")
+		return "", nil
+	}
+}
+
+func TestHandshake_ResumeWithIK(t *testing.T) {
+	root, cli := newTestHandshakeEnv(t)
+	peer := newTestHandshakePeer(root)
+
+	pattern, err := connectToTestPeer(t, cli, peer)
+	if err != nil {
+		t.Fatalf("Full handshake failed: %v", err)
+	} else if pattern != "XX" {
+		t.Fatalf("Expected XX handshake without cached key, got %s", pattern)
+	} else if !bytes.Equal(cli.Store.ServerStaticKey, peer.staticKey.Pub[:]) {
+		t.Fatalf("Server static key wasn't cached after full handshake")
+	}
+
+	pattern, err = connectToTestPeer(t, cli, peer)
+	if err != nil {
+		t.Fatalf("Resume handshake failed: %v", err)
+	} else if pattern != "IK" {
+		t.Fatalf("Expected IK handshake with cached key, got %s", pattern)
+	}
+}
+
+func TestHandshake_FallbackToXX(t *testing.T) {
+	root, cli := newTestHandshakeEnv(t)
+	peer := newTestHandshakePeer(root)
+	cli.Store.ServerStaticKey = keys.NewKeyPair().Pub[:]
+
+	pattern, err := connectToTestPeer(t, cli, peer)
+	if err != nil {
+		t.Fatalf("Fallback handshake failed: %v", err)
+	} else if pattern != "XXfallback" {
+		t.Fatalf("Expected XXfallback handshake with outdated key, got %s", pattern)
+	} else if !bytes.Equal(cli.Store.ServerStaticKey, peer.staticKey.Pub[:]) {
+		t.Fatalf("Server static key wasn't updated after fallback handshake")
+	}
+}
+
+func TestHandshake_FallbackRequiresValidCert(t *testing.T) {
+	_, cli := newTestHandshakeEnv(t)
+	// The peer's certificate isn't signed by the trusted root key
+	peer := newTestHandshakePeer(keys.NewKeyPair())
+	oldKey := keys.NewKeyPair().Pub[:]
+	cli.Store.ServerStaticKey = oldKey
+
+	_, err := connectToTestPeer(t, cli, peer)
+	if err == nil || !strings.Contains(err.Error(), "failed to verify server cert") {
+		t.Fatalf("Expected cert verification error, got %v", err)
+	} else if !bytes.Equal(cli.Store.ServerStaticKey, oldKey) {
+		t.Fatalf("Server static key was changed after failed fallback handshake")
+	}
+}
+
+func TestHandshake_FailedResumeClearsKey(t *testing.T) {
+	root, cli := newTestHandshakeEnv(t)
+	peer := newTestHandshakePeer(root)
+	peer.corruptResume = true
+	cli.Store.ServerStaticKey = peer.staticKey.Pub[:]
+
+	_, err := connectToTestPeer(t, cli, peer)
+	if err == nil {
+		t.Fatalf("Resume handshake with corrupted response succeeded")
+	} else if cli.Store.ServerStaticKey != nil {
+		t.Fatalf("Server static key wasn't cleared after failed resume handshake")
+	}
+}
+
+func TestHandshake_FailedResumeTransportKeepsKey(t *testing.T) {
+	root, cli := newTestHandshakeEnv(t)
+	peer := newTestHandshakePeer(root)
+	peer.brokenResume = true
+	cli.Store.ServerStaticKey = peer.staticKey.Pub[:]
+
+	_, err := connectToTestPeer(t, cli, peer)
+	if err == nil {
+		t.Fatalf("Resume handshake with broken response succeeded")
+	} else if !bytes.Equal(cli.Store.ServerStaticKey, peer.staticKey.Pub[:]) {
+		t.Fatalf("Server static key was cleared after resume handshake failed without decryption error")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"go.mau.fi/whatsmeow/proto/waMsgApplication"
 	"go.mau.fi/whatsmeow/proto/waMsgTransport"
 	"go.mau.fi/whatsmeow/proto/waServerSync"
+	"go.mau.fi/whatsmeow/proto/waWa6"
 	"go.mau.fi/whatsmeow/socket"
 	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/types"
@@ -91,14 +92,22 @@ func (int *DangerousInternalClient) HandleDecryptedArmadillo(ctx context.Context
 	return int.c.handleDecryptedArmadillo(ctx, info, decrypted, retryCount)
 }
 
-func (int *DangerousInternalClient) GetBroadcastListParticipants(ctx context.Context, jid types.JID) ([]types.JID, error) {
-	return int.c.getBroadcastListParticipants(ctx, jid)
+func (int *DangerousInternalClient) GetBroadcastListParticipants(ctx context.Context, jid types.JID, override []types.JID) ([]types.JID, error) {
+	return int.c.getBroadcastListParticipants(ctx, jid, override)
+}
+
+func (int *DangerousInternalClient) GetBroadcastListRecipients(ctx context.Context, jid types.JID) ([]types.JID, error) {
+	return int.c.getBroadcastListRecipients(ctx, jid)
 }
 
 func (int *DangerousInternalClient) GetStatusBroadcastRecipients(ctx context.Context) ([]types.JID, error) {
 	return int.c.getStatusBroadcastRecipients(ctx)
 }
 
+func (int *DangerousInternalClient) GetStatusAudienceRecipients(ctx context.Context, statusPrivacy types.StatusPrivacy) ([]types.JID, error) {
+	return int.c.getStatusAudienceRecipients(ctx, statusPrivacy)
+}
+
 func (int *DangerousInternalClient) HandleCallEvent(ctx context.Context, node *waBinary.Node) {
 	int.c.handleCallEvent(ctx, node)
 }
@@ -191,6 +200,14 @@ func (int *DangerousInternalClient) HandleIB(ctx context.Context, node *waBinary
 	int.c.handleIB(ctx, node)
 }
 
+func (int *DangerousInternalClient) UpdateEdgeRoutingInfo(ctx context.Context, routingInfo []byte) {
+	int.c.updateEdgeRoutingInfo(ctx, routingInfo)
+}
+
+func (int *DangerousInternalClient) ClearEdgeRoutingInfo(ctx context.Context) {
+	int.c.clearEdgeRoutingInfo(ctx)
+}
+
 func (int *DangerousInternalClient) HandleConnectFailure(ctx context.Context, node *waBinary.Node) {
 	int.c.handleConnectFailure(ctx, node)
 }
@@ -275,6 +292,30 @@ func (int *DangerousInternalClient) DoHandshake(ctx context.Context, fs *socket.
 	return int.c.doHandshake(ctx, fs, ephemeralKP)
 }
 
+func (int *DangerousInternalClient) GetClientPayloadBytes() ([]byte, error) {
+	return int.c.getClientPayloadBytes()
+}
+
+func (int *DangerousInternalClient) SendResumeHandshake(fs *socket.FrameSocket, header []byte, ephemeralKP keys.KeyPair, serverStatic [32]byte) (*socket.NoiseHandshake, *waWa6.HandshakeMessage_ServerHello, error) {
+	return int.c.sendResumeHandshake(fs, header, ephemeralKP, serverStatic)
+}
+
+func (int *DangerousInternalClient) FinishResumeHandshake(ctx context.Context, fs *socket.FrameSocket, nh *socket.NoiseHandshake, ephemeralKP keys.KeyPair, serverHello *waWa6.HandshakeMessage_ServerHello) error {
+	return int.c.finishResumeHandshake(ctx, fs, nh, ephemeralKP, serverHello)
+}
+
+func (int *DangerousInternalClient) FinishFullHandshake(ctx context.Context, fs *socket.FrameSocket, nh *socket.NoiseHandshake, ephemeralKP keys.KeyPair, serverHello *waWa6.HandshakeMessage_ServerHello) error {
+	return int.c.finishFullHandshake(ctx, fs, nh, ephemeralKP, serverHello)
+}
+
+func (int *DangerousInternalClient) UpdateServerStaticKey(ctx context.Context, serverStatic []byte) {
+	int.c.updateServerStaticKey(ctx, serverStatic)
+}
+
+func (int *DangerousInternalClient) ClearServerStaticKey(ctx context.Context) {
+	int.c.clearServerStaticKey(ctx)
+}
+
 func (int *DangerousInternalClient) KeepAliveLoop(ctx, connCtx context.Context) {
 	int.c.keepAliveLoop(ctx, connCtx)
 }
@@ -459,6 +500,10 @@ func (int *DangerousInternalClient) HandleNewsletterNotification(ctx context.Con
 	int.c.handleNewsletterNotification(ctx, node)
 }
 
+func (int *DangerousInternalClient) HandleCommunityOwnerUpdate(data []byte) {
+	int.c.handleCommunityOwnerUpdate(data)
+}
+
 func (int *DangerousInternalClient) HandleMexNotification(ctx context.Context, node *waBinary.Node) {
 	int.c.handleMexNotification(ctx, node)
 }
@@ -527,6 +572,10 @@ func (int *DangerousInternalClient) HandlePresence(ctx context.Context, node *wa
 	int.c.handlePresence(ctx, node)
 }
 
+func (int *DangerousInternalClient) SendPresenceSubscription(ctx context.Context, jid types.JID) error {
+	return int.c.sendPresenceSubscription(ctx, jid)
+}
+
 func (int *DangerousInternalClient) ParsePrivacySettings(privacyNode *waBinary.Node, settings *types.PrivacySettings) *events.PrivacySettings {
 	return int.c.parsePrivacySettings(privacyNode, settings)
 }
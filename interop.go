@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+type mexInteropParticipantInput struct {
+	JID types.JID `json:"jid"`
+}
+
+type mexCreateInteropGroupInput struct {
+	Subject      string                       `json:"subject"`
+	Participants []mexInteropParticipantInput `json:"participants"`
+}
+
+type mexCreateInteropGroupVars struct {
+	Input mexCreateInteropGroupInput `json:"input"`
+}
+
+type respCreateInteropGroup struct {
+	Result *struct {
+		Group                *types.InteropGroupInfo            `json:"group"`
+		ParticipantResponses []types.InteropParticipantResponse `json:"participant_responses"`
+	} `json:"xwa2_interop_group_create"`
+}
+
+type mexAddInteropParticipantsInput struct {
+	GroupID      string                       `json:"gid"`
+	Participants []mexInteropParticipantInput `json:"participants"`
+}
+
+type mexAddInteropParticipantsVars struct {
+	Input mexAddInteropParticipantsInput `json:"input"`
+}
+
+type respAddInteropParticipants struct {
+	Result *struct {
+		ParticipantResponses []types.InteropParticipantResponse `json:"participant_responses"`
+	} `json:"xwa2_interop_group_add_participants"`
+}
+
+type mexInteropGroupQueryVars struct {
+	Input struct {
+		GroupID string `json:"gid"`
+	} `json:"input"`
+}
+
+type respQueryInteropGroupInfo struct {
+	Group *types.InteropGroupInfo `json:"xwa2_interop_group_query_by_id"`
+}
+
+var (
+	mexCreateInteropGroup = RegisterMexOperation[mexCreateInteropGroupVars, respCreateInteropGroup](
+		"GroupsCreateInteropGroup", MexQueryIDs{},
+	)
+	mexAddInteropParticipants = RegisterMexOperation[mexAddInteropParticipantsVars, respAddInteropParticipants](
+		"AddParticipantsToInteropGroup", MexQueryIDs{},
+	)
+	mexQueryInteropGroupInfo = RegisterMexOperation[mexInteropGroupQueryVars, respQueryInteropGroupInfo](
+		"QueryInteropGroupInfo", MexQueryIDs{},
+	)
+)
+
+func makeInteropParticipantInputs(participants []types.JID) []mexInteropParticipantInput {
+	inputs := make([]mexInteropParticipantInput, len(participants))
+	for i, participant := range participants {
+		inputs[i] = mexInteropParticipantInput{JID: participant.ToNonAD()}
+	}
+	return inputs
+}
+
+// CreateInteropGroup creates a group that can include users of third-party messengers.
+//
+// The participants can be normal WhatsApp users or interop users (see types.NewInteropJID).
+// The returned responses contain the result of adding each participant.
+func (cli *Client) CreateInteropGroup(ctx context.Context, name string, participants []types.JID) (*types.InteropGroupInfo, []types.InteropParticipantResponse, error) {
+	resp, err := SendMexOperation(ctx, cli, mexCreateInteropGroup, mexCreateInteropGroupVars{
+		Input: mexCreateInteropGroupInput{
+			Subject:      name,
+			Participants: makeInteropParticipantInputs(participants),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	} else if resp.Result == nil || resp.Result.Group == nil {
+		return nil, nil, &ElementMissingError{Tag: "xwa2_interop_group_create", In: "response to interop group create"}
+	}
+	return resp.Result.Group, resp.Result.ParticipantResponses, nil
+}
+
+// AddInteropGroupParticipants adds the given users to an interop group.
+func (cli *Client) AddInteropGroupParticipants(ctx context.Context, group types.JID, participants []types.JID) ([]types.InteropParticipantResponse, error) {
+	resp, err := SendMexOperation(ctx, cli, mexAddInteropParticipants, mexAddInteropParticipantsVars{
+		Input: mexAddInteropParticipantsInput{
+			GroupID:      group.User,
+			Participants: makeInteropParticipantInputs(participants),
+		},
+	})
+	if err != nil {
+		return nil, err
+	} else if resp.Result == nil {
+		return nil, ErrGroupNotFound
+	}
+	return resp.Result.ParticipantResponses, nil
+}
+
+// GetInteropGroupInfo gets info about an interop group, including the participants from third-party messengers.
+func (cli *Client) GetInteropGroupInfo(ctx context.Context, group types.JID) (*types.InteropGroupInfo, error) {
+	var vars mexInteropGroupQueryVars
+	vars.Input.GroupID = group.User
+	resp, err := SendMexOperation(ctx, cli, mexQueryInteropGroupInfo, vars)
+	if err != nil {
+		return nil, err
+	} else if resp.Group == nil {
+		return nil, ErrGroupNotFound
+	}
+	return resp.Group, nil
+}
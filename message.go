@@ -478,7 +478,14 @@ func (cli *Client) clearUntrustedIdentity(ctx context.Context, target types.JID)
 	if err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
-	go cli.dispatchEvent(&events.IdentityChange{JID: target, Timestamp: time.Now(), Implicit: true})
+	ts := time.Now()
+	verifiedEvt := cli.checkVerifiedIdentityChange(ctx, target, ts, true)
+	go func() {
+		cli.dispatchEvent(&events.IdentityChange{JID: target, Timestamp: ts, Implicit: true})
+		if verifiedEvt != nil {
+			cli.dispatchEvent(verifiedEvt)
+		}
+	}()
 	return nil
 }
 
@@ -1035,7 +1042,16 @@ func (cli *Client) handleDecryptedMessage(ctx context.Context, info *types.Messa
 		return false
 	}
 	evt := &events.Message{Info: *info, RawMessage: msg, RetryCount: retryCount}
-	return cli.dispatchEvent(evt.UnwrapRaw())
+	handlerFailed = cli.dispatchEvent(evt.UnwrapRaw())
+	if info.Sender.IsBot() && !info.IsFromMe {
+		cli.handleBotResponseChunk(info, evt.Message)
+	}
+	cli.trackPollCreation(ctx, info, evt.Message)
+	cli.handlePollVote(ctx, evt)
+	if bundle := evt.Message.GetMessageHistoryBundle(); bundle != nil && !cli.ManualHistorySyncDownload {
+		go cli.handleGroupHistoryBundle(context.WithoutCancel(ctx), info, bundle)
+	}
+	return
 }
 
 func (cli *Client) sendProtocolMessageReceipt(ctx context.Context, id types.MessageID, msgType types.ReceiptType) {
@@ -0,0 +1,182 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"go.mau.fi/whatsmeow/argo"
+	"go.mau.fi/whatsmeow/proto/waWa6"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// Error codes used in the GraphQLErrors returned for mex operations that can't be sent at all.
+const (
+	MexErrorCodeUnknownOperation = 404
+	MexErrorCodeTypeMismatch     = 400
+)
+
+// MexQueryIDs contains the query IDs of a single GraphQL operation for the different client platforms.
+type MexQueryIDs struct {
+	// Web is the query ID used by WhatsApp web clients. If empty, the desktop ID is used for all clients.
+	Web string
+	// Desktop is the query ID used by desktop and mobile clients.
+	// If empty, the ID is looked up from argo/name-to-queryids.json using the operation name.
+	Desktop string
+}
+
+type mexRegistryEntry struct {
+	IDs      MexQueryIDs
+	VarsType reflect.Type
+	RespType reflect.Type
+}
+
+var (
+	mexRegistry     = make(map[string]*mexRegistryEntry)
+	mexRegistryLock sync.RWMutex
+)
+
+// MexOperation is a typed handle for a GraphQL operation sent over the w:mex namespace.
+//
+// Operations are created with RegisterMexOperation and sent with SendMexOperation.
+type MexOperation[Vars, Resp any] struct {
+	Name string
+}
+
+// RegisterMexOperation registers the request and response types of a GraphQL operation and returns a typed handle for it.
+//
+// The name must be the operation name used in argo/name-to-queryids.json. The query IDs can be left empty
+// to use the ID from that file for all clients. Registering the same name again will replace the previous entry.
+//
+//	type MyVars struct {
+//		Input struct{ Key string `json:"key"` } `json:"input"`
+//	}
+//	type MyResp struct {
+//		Newsletter *types.NewsletterMetadata `json:"xwa2_newsletter"`
+//	}
+//	var myOperation = whatsmeow.RegisterMexOperation[MyVars, MyResp]("NewsletterMetadata", whatsmeow.MexQueryIDs{})
+//	resp, err := whatsmeow.SendMexOperation(ctx, cli, myOperation, MyVars{...})
+func RegisterMexOperation[Vars, Resp any](name string, ids MexQueryIDs) MexOperation[Vars, Resp] {
+	mexRegistryLock.Lock()
+	mexRegistry[name] = &mexRegistryEntry{
+		IDs:      ids,
+		VarsType: reflect.TypeFor[Vars](),
+		RespType: reflect.TypeFor[Resp](),
+	}
+	mexRegistryLock.Unlock()
+	return MexOperation[Vars, Resp]{Name: name}
+}
+
+// GetMexQueryIDs returns the query IDs for the given operation name, or false if the operation is not known
+// (i.e. it's neither registered with RegisterMexOperation nor present in argo/name-to-queryids.json).
+func GetMexQueryIDs(name string) (MexQueryIDs, bool) {
+	mexRegistryLock.RLock()
+	entry, ok := mexRegistry[name]
+	mexRegistryLock.RUnlock()
+	var ids MexQueryIDs
+	if ok {
+		ids = entry.IDs
+	}
+	if ids.Desktop == "" {
+		nameMap, err := argo.GetMessageNameToQueryID()
+		if err == nil {
+			ids.Desktop = nameMap[name]
+		}
+	}
+	if ids.Desktop == "" {
+		ids.Desktop = ids.Web
+	}
+	return ids, ids.Desktop != ""
+}
+
+func mexUnknownOperationError(name string) error {
+	return types.GraphQLErrors{{
+		Message: fmt.Sprintf("unknown mex operation %q", name),
+		Path:    []string{name},
+		Extensions: types.GraphQLErrorExtensions{
+			ErrorCode: MexErrorCodeUnknownOperation,
+			Severity:  "CRITICAL",
+		},
+	}}
+}
+
+func mexTypeMismatchError(name string, entry *mexRegistryEntry, varsType, respType reflect.Type) error {
+	return types.GraphQLErrors{{
+		Message: fmt.Sprintf(
+			"mex operation %q is registered as %s -> %s, but was called with %s -> %s",
+			name, entry.VarsType, entry.RespType, varsType, respType,
+		),
+		Path: []string{name},
+		Extensions: types.GraphQLErrorExtensions{
+			ErrorCode: MexErrorCodeTypeMismatch,
+			Severity:  "CRITICAL",
+		},
+	}}
+}
+
+func (cli *Client) useDesktopQueryIDs() bool {
+	payload := cli.Store.GetClientPayload()
+	return payload.GetUserAgent().GetPlatform() == waWa6.ClientPayload_UserAgent_MACOS || payload.GetWebInfo() == nil
+}
+
+func (cli *Client) resolveMexQueryID(name string) (string, error) {
+	ids, ok := GetMexQueryIDs(name)
+	if !ok {
+		return "", mexUnknownOperationError(name)
+	} else if ids.Web != "" && !cli.useDesktopQueryIDs() {
+		return ids.Web, nil
+	}
+	return ids.Desktop, nil
+}
+
+// SendMexQuery sends a GraphQL query or mutation by its operation name and returns the raw data field of the response.
+//
+// Any operation in argo/name-to-queryids.json can be sent this way. If the operation is not known,
+// the returned error will be a types.GraphQLErrors with MexErrorCodeUnknownOperation as the error code.
+func (cli *Client) SendMexQuery(ctx context.Context, name string, variables any) (json.RawMessage, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	queryID, err := cli.resolveMexQueryID(name)
+	if err != nil {
+		return nil, err
+	}
+	return cli.sendMexIQ(ctx, queryID, variables)
+}
+
+// SendMexOperation sends a registered GraphQL operation and parses the response into the operation's response type.
+//
+// If the server returns both data and errors, the partially filled response is returned along with the error.
+func SendMexOperation[Vars, Resp any](ctx context.Context, cli *Client, op MexOperation[Vars, Resp], variables Vars) (*Resp, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	mexRegistryLock.RLock()
+	entry, ok := mexRegistry[op.Name]
+	mexRegistryLock.RUnlock()
+	if !ok {
+		return nil, mexUnknownOperationError(op.Name)
+	}
+	varsType, respType := reflect.TypeFor[Vars](), reflect.TypeFor[Resp]()
+	if entry.VarsType != varsType || entry.RespType != respType {
+		return nil, mexTypeMismatchError(op.Name, entry, varsType, respType)
+	}
+	data, err := cli.SendMexQuery(ctx, op.Name, variables)
+	if data == nil {
+		return nil, err
+	}
+	var resp Resp
+	jsonErr := json.Unmarshal(data, &resp)
+	if err == nil && jsonErr != nil {
+		err = fmt.Errorf("failed to unmarshal %s response: %w", op.Name, jsonErr)
+	}
+	return &resp, err
+}
@@ -138,8 +138,20 @@ const (
 	mutationFollowNewsletterDesktop    = "8621797084555037"
 )
 
+var (
+	mexFetchNewsletter = RegisterMexOperation[mexFetchNewsletterVars, respGetNewsletterInfo](
+		"NewsletterMetadata", MexQueryIDs{Web: queryFetchNewsletter, Desktop: queryFetchNewsletterDesktop},
+	)
+	mexSubscribedNewsletters = RegisterMexOperation[struct{}, respGetSubscribedNewsletters](
+		"NewsletterSubscribed", MexQueryIDs{Web: querySubscribedNewsletters, Desktop: querySubscribedNewslettersDesktop},
+	)
+	mexCreateNewsletter = RegisterMexOperation[mexCreateNewsletterVars, respCreateNewsletter](
+		"NewsletterCreate", MexQueryIDs{Web: mutationCreateNewsletter, Desktop: mutationCreateNewsletterDesktop},
+	)
+)
+
 func convertQueryID(cli *Client, queryID string) string {
-	if payload := cli.Store.GetClientPayload(); payload.GetUserAgent().Platform == waWa6.ClientPayload_UserAgent_MACOS.Enum() || payload.GetWebInfo() == nil {
+	if cli.useDesktopQueryIDs() {
 		switch queryID {
 		case queryFetchNewsletter:
 			return queryFetchNewsletterDesktop
@@ -246,21 +258,24 @@ type respGetNewsletterInfo struct {
 	Newsletter *types.NewsletterMetadata `json:"xwa2_newsletter"`
 }
 
+type mexFetchNewsletterVars struct {
+	FetchCreationTime   bool           `json:"fetch_creation_time"`
+	FetchFullImage      bool           `json:"fetch_full_image"`
+	FetchViewerMetadata bool           `json:"fetch_viewer_metadata"`
+	Input               map[string]any `json:"input"`
+}
+
 func (cli *Client) getNewsletterInfo(ctx context.Context, input map[string]any, fetchViewerMeta bool) (*types.NewsletterMetadata, error) {
-	data, err := cli.sendMexIQ(ctx, queryFetchNewsletter, map[string]any{
-		"fetch_creation_time":   true,
-		"fetch_full_image":      true,
-		"fetch_viewer_metadata": fetchViewerMeta,
-		"input":                 input,
+	resp, err := SendMexOperation(ctx, cli, mexFetchNewsletter, mexFetchNewsletterVars{
+		FetchCreationTime:   true,
+		FetchFullImage:      true,
+		FetchViewerMetadata: fetchViewerMeta,
+		Input:               input,
 	})
-	var respData respGetNewsletterInfo
-	if data != nil {
-		jsonErr := json.Unmarshal(data, &respData)
-		if err == nil && jsonErr != nil {
-			err = jsonErr
-		}
+	if resp == nil {
+		return nil, err
 	}
-	return respData.Newsletter, err
+	return resp.Newsletter, err
 }
 
 // GetNewsletterInfo gets the info of a newsletter that you're joined to.
@@ -289,15 +304,11 @@ type respGetSubscribedNewsletters struct {
 
 // GetSubscribedNewsletters gets the info of all newsletters that you're joined to.
 func (cli *Client) GetSubscribedNewsletters(ctx context.Context) ([]*types.NewsletterMetadata, error) {
-	data, err := cli.sendMexIQ(ctx, querySubscribedNewsletters, map[string]any{})
-	var respData respGetSubscribedNewsletters
-	if data != nil {
-		jsonErr := json.Unmarshal(data, &respData)
-		if err == nil && jsonErr != nil {
-			err = jsonErr
-		}
+	resp, err := SendMexOperation(ctx, cli, mexSubscribedNewsletters, struct{}{})
+	if resp == nil {
+		return nil, err
 	}
-	return respData.Newsletters, err
+	return resp.Newsletters, err
 }
 
 type CreateNewsletterParams struct {
@@ -310,20 +321,17 @@ type respCreateNewsletter struct {
 	Newsletter *types.NewsletterMetadata `json:"xwa2_newsletter_create"`
 }
 
+type mexCreateNewsletterVars struct {
+	Input *CreateNewsletterParams `json:"newsletter_input"`
+}
+
 // CreateNewsletter creates a new WhatsApp channel.
 func (cli *Client) CreateNewsletter(ctx context.Context, params CreateNewsletterParams) (*types.NewsletterMetadata, error) {
-	resp, err := cli.sendMexIQ(ctx, mutationCreateNewsletter, map[string]any{
-		"newsletter_input": &params,
-	})
-	if err != nil {
-		return nil, err
-	}
-	var respData respCreateNewsletter
-	err = json.Unmarshal(resp, &respData)
+	resp, err := SendMexOperation(ctx, cli, mexCreateNewsletter, mexCreateNewsletterVars{Input: &params})
 	if err != nil {
 		return nil, err
 	}
-	return respData.Newsletter, nil
+	return resp.Newsletter, nil
 }
 
 // AcceptTOSNotice accepts a ToS notice.
@@ -47,7 +47,11 @@ func (cli *Client) handleEncryptNotification(ctx context.Context, node *waBinary
 			cli.Log.Warnf("Failed to delete all sessions of %s from store after identity change: %v", from, err)
 		}
 		ts := node.AttrGetter().UnixTime("t")
+		verifiedEvt := cli.checkVerifiedIdentityChange(ctx, from, ts, false)
 		cli.dispatchEvent(&events.IdentityChange{JID: from, Timestamp: ts})
+		if verifiedEvt != nil {
+			cli.dispatchEvent(verifiedEvt)
+		}
 	} else {
 		cli.Log.Debugf("Got unknown encryption notification from server: %s", node.XMLString())
 	}
@@ -354,17 +358,48 @@ func (cli *Client) handleNewsletterNotification(ctx context.Context, node *waBin
 	})
 }
 
-type newsLetterEventWrapper struct {
-	Data newsletterEvent `json:"data"`
+type mexEventWrapper struct {
+	Data mexEvent `json:"data"`
 }
 
-type newsletterEvent struct {
+type mexEvent struct {
 	Join       *events.NewsletterJoin       `json:"xwa2_notify_newsletter_on_join"`
 	Leave      *events.NewsletterLeave      `json:"xwa2_notify_newsletter_on_leave"`
 	MuteChange *events.NewsletterMuteChange `json:"xwa2_notify_newsletter_on_mute_change"`
 	// _on_admin_metadata_update -> id, thread_metadata, messages
 	// _on_metadata_update
 	// _on_state_change -> id, is_requestor, state
+
+	CommunityRolesChange *events.CommunityParticipantRolesChange `json:"xwa2_notify_group_on_participants_roles_change"`
+	GroupPropertyChange  *events.GroupPropertyChange             `json:"xwa2_notify_group_on_prop_change"`
+
+	ParticipantLabelChange *events.GroupParticipantLabelChange `json:"xwa2_notify_group_on_participant_property_change"`
+
+	InteropGroupCreate             *events.InteropGroupCreate             `json:"xwa2_notify_interop_group_on_create"`
+	InteropGroupParticipantsChange *events.InteropGroupParticipantsChange `json:"xwa2_notify_interop_group_on_participants_change"`
+}
+
+const mexNotificationCommunityOwnerUpdate = "NotificationCommunityOwnerUpdate"
+
+// handleCommunityOwnerUpdate handles NotificationCommunityOwnerUpdate mex notifications.
+// The payload has the same fields as other community role changes, but the name of its root key isn't known,
+// so whichever non-null field of the data object is found is dispatched. The payload is expected to only have one.
+func (cli *Client) handleCommunityOwnerUpdate(data []byte) {
+	var wrapper struct {
+		Data map[string]*events.CommunityParticipantRolesChange `json:"data"`
+	}
+	err := json.Unmarshal(data, &wrapper)
+	if err != nil {
+		cli.Log.Errorf("Failed to unmarshal JSON in community owner update: %v", err)
+		return
+	}
+	for _, evt := range wrapper.Data {
+		if evt != nil {
+			cli.dispatchEvent(evt)
+			return
+		}
+	}
+	cli.Log.Warnf("Community owner update notification didn't contain any data")
 }
 
 func (cli *Client) handleMexNotification(ctx context.Context, node *waBinary.Node) {
@@ -376,7 +411,11 @@ func (cli *Client) handleMexNotification(ctx context.Context, node *waBinary.Nod
 		if !ok {
 			continue
 		}
-		var wrapper newsLetterEventWrapper
+		if child.AttrGetter().OptionalString("op_name") == mexNotificationCommunityOwnerUpdate {
+			cli.handleCommunityOwnerUpdate(childData)
+			continue
+		}
+		var wrapper mexEventWrapper
 		err := json.Unmarshal(childData, &wrapper)
 		if err != nil {
 			cli.Log.Errorf("Failed to unmarshal JSON in mex event: %v", err)
@@ -388,8 +427,45 @@ func (cli *Client) handleMexNotification(ctx context.Context, node *waBinary.Nod
 			cli.dispatchEvent(wrapper.Data.Leave)
 		} else if wrapper.Data.MuteChange != nil {
 			cli.dispatchEvent(wrapper.Data.MuteChange)
+		} else if wrapper.Data.CommunityRolesChange != nil {
+			cli.dispatchEvent(wrapper.Data.CommunityRolesChange)
+		} else if wrapper.Data.GroupPropertyChange != nil {
+			cli.dispatchEvent(wrapper.Data.GroupPropertyChange)
+			if groupInfoEvt := groupInfoFromPropertyChange(wrapper.Data.GroupPropertyChange); groupInfoEvt != nil {
+				cli.updateStoredGroupInfo(ctx, groupInfoEvt, nil)
+				cli.dispatchEvent(groupInfoEvt)
+			}
+		} else if wrapper.Data.ParticipantLabelChange != nil {
+			cli.updateStoredParticipantLabel(ctx, wrapper.Data.ParticipantLabelChange)
+			cli.dispatchEvent(wrapper.Data.ParticipantLabelChange)
+		} else if wrapper.Data.InteropGroupCreate != nil {
+			cli.dispatchEvent(wrapper.Data.InteropGroupCreate)
+		} else if wrapper.Data.InteropGroupParticipantsChange != nil {
+			cli.dispatchEvent(wrapper.Data.InteropGroupParticipantsChange)
+		}
+	}
+}
+
+func groupInfoFromPropertyChange(change *events.GroupPropertyChange) *events.GroupInfo {
+	props := change.Properties
+	if props.MemberLinkMode == nil && props.ParticipantLabelEnabled == nil && props.SafetyCheck == nil && props.Hidden == nil {
+		return nil
+	}
+	evt := &events.GroupInfo{
+		JID:                     change.ID,
+		Timestamp:               change.UpdateTime.Time,
+		MemberLinkMode:          props.MemberLinkMode,
+		ParticipantLabelEnabled: props.ParticipantLabelEnabled,
+		SafetyCheck:             props.SafetyCheck,
+		Hidden:                  props.Hidden,
+	}
+	if change.UpdatedBy != nil && !change.UpdatedBy.JID.IsEmpty() {
+		evt.Sender = &change.UpdatedBy.JID
+		if !change.UpdatedBy.PN.IsEmpty() {
+			evt.SenderPN = &change.UpdatedBy.PN
 		}
 	}
+	return evt
 }
 
 func (cli *Client) handleStatusNotification(ctx context.Context, node *waBinary.Node) {
@@ -443,6 +519,7 @@ func (cli *Client) handleNotification(ctx context.Context, node *waBinary.Node)
 			if err != nil {
 				cli.Log.Warnf("Failed to store redacted phones from group notification: %v", err)
 			}
+			cli.updateStoredGroupInfo(ctx, evt, lidPairs)
 			cancelled = cli.dispatchEvent(evt)
 		}
 	case "picture":
@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func getPollCreation(msg *waE2E.Message) *waE2E.PollCreationMessage {
+	if poll := msg.GetPollCreationMessage(); poll != nil {
+		return poll
+	} else if poll = msg.GetPollCreationMessageV2(); poll != nil {
+		return poll
+	}
+	return msg.GetPollCreationMessageV3()
+}
+
+func (cli *Client) trackPollCreation(ctx context.Context, info *types.MessageInfo, msg *waE2E.Message) {
+	poll := getPollCreation(msg)
+	if poll == nil || cli.DisablePollTracking || cli.Store.Polls == nil {
+		return
+	}
+	pollInfo := &types.PollInfo{
+		Chat:            info.Chat,
+		ID:              info.ID,
+		Creator:         info.Sender.ToNonAD(),
+		Name:            poll.GetName(),
+		Options:         make([]string, len(poll.GetOptions())),
+		SelectableCount: int(poll.GetSelectableOptionsCount()),
+		CreatedAt:       info.Timestamp,
+	}
+	for i, opt := range poll.GetOptions() {
+		pollInfo.Options[i] = opt.GetOptionName()
+	}
+	err := cli.Store.Polls.PutPoll(ctx, pollInfo)
+	if err != nil {
+		cli.Log.Warnf("Failed to store poll %s in %s: %v", info.ID, info.Chat, err)
+	}
+}
+
+func (cli *Client) handlePollVote(ctx context.Context, evt *events.Message) {
+	pollUpdate := evt.Message.GetPollUpdateMessage()
+	if pollUpdate == nil || cli.DisablePollTracking || cli.Store.Polls == nil {
+		return
+	}
+	pollID := pollUpdate.GetPollCreationMessageKey().GetID()
+	vote, err := cli.DecryptPollVote(ctx, evt)
+	if err != nil {
+		cli.Log.Warnf("Failed to decrypt vote %s to poll %s for tracking: %v", evt.Info.ID, pollID, err)
+		return
+	}
+	ts := evt.Info.Timestamp
+	if senderTS := pollUpdate.GetSenderTimestampMS(); senderTS > 0 {
+		ts = time.UnixMilli(senderTS)
+	}
+	stored, err := cli.Store.Polls.PutPollVote(ctx, evt.Info.Chat, pollID, types.PollVote{
+		Voter:          evt.Info.Sender.ToNonAD(),
+		SelectedHashes: vote.GetSelectedOptions(),
+		Timestamp:      ts,
+	})
+	if err != nil {
+		cli.Log.Warnf("Failed to store vote %s to poll %s: %v", evt.Info.ID, pollID, err)
+		return
+	} else if !stored {
+		cli.Log.Debugf("Ignoring vote %s to poll %s as a newer vote from %s is already stored", evt.Info.ID, pollID, evt.Info.Sender)
+		return
+	}
+	results, err := cli.GetPollResults(ctx, evt.Info.Chat, pollID)
+	if err != nil {
+		cli.Log.Warnf("Failed to get results of poll %s after vote: %v", pollID, err)
+	} else if results != nil {
+		cli.dispatchEvent(&events.PollResultsChanged{
+			Info:    evt.Info,
+			Voter:   evt.Info.Sender.ToNonAD(),
+			Results: results,
+		})
+	}
+}
+
+// GetPollResults returns the current results of a tracked poll, or nil if the poll isn't tracked.
+//
+// Polls are tracked automatically when their creation message is received or sent by this device,
+// and received votes (including votes sent from the user's other devices) are decrypted and stored as they arrive.
+// Only the latest vote of each user is counted.
+func (cli *Client) GetPollResults(ctx context.Context, chat types.JID, pollID types.MessageID) (*types.PollResults, error) {
+	poll, err := cli.Store.Polls.GetPoll(ctx, chat, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	} else if poll == nil {
+		return nil, nil
+	}
+	votes, err := cli.Store.Polls.GetPollVotes(ctx, chat, pollID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll votes: %w", err)
+	}
+	return tallyPollVotes(poll, votes), nil
+}
+
+func tallyPollVotes(poll *types.PollInfo, votes []types.PollVote) *types.PollResults {
+	results := &types.PollResults{
+		Poll:    *poll,
+		Options: make([]types.PollOptionResult, len(poll.Options)),
+	}
+	optionIndexes := make(map[string]int, len(poll.Options))
+	for i, hash := range HashPollOptions(poll.Options) {
+		results.Options[i] = types.PollOptionResult{
+			Name:   poll.Options[i],
+			Hash:   hash,
+			Voters: []types.JID{},
+		}
+		optionIndexes[string(hash)] = i
+	}
+	// Sort the votes so that voter lists are in the order the votes were cast
+	slices.SortFunc(votes, func(a, b types.PollVote) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	for _, vote := range votes {
+		counted := false
+		for _, hash := range vote.SelectedHashes {
+			idx, ok := optionIndexes[string(hash)]
+			if !ok {
+				continue
+			}
+			results.Options[idx].Voters = append(results.Options[idx].Voters, vote.Voter)
+			counted = true
+		}
+		if counted {
+			results.TotalVoters++
+		}
+		if vote.Timestamp.After(results.UpdatedAt) {
+			results.UpdatedAt = vote.Timestamp
+		}
+	}
+	return results
+}
@@ -28,11 +28,13 @@ func (cli *Client) handleChatState(ctx context.Context, node *waBinary.Node) {
 			cli.Log.Warnf("Unrecognized chat presence state %s", child.Tag)
 		}
 		media := types.ChatPresenceMedia(child.AttrGetter().OptionalString("media"))
-		cli.dispatchEvent(&events.ChatPresence{
+		evt := &events.ChatPresence{
 			MessageSource: source,
 			State:         presence,
 			Media:         media,
-		})
+		}
+		cli.presenceTracker.handleChatPresence(evt)
+		cli.dispatchEvent(evt)
 	}
 }
 
@@ -53,6 +55,7 @@ func (cli *Client) handlePresence(ctx context.Context, node *waBinary.Node) {
 	if !ag.OK() {
 		cli.Log.Warnf("Error parsing presence event: %+v", ag.Errors)
 	} else {
+		cli.presenceTracker.handlePresence(&evt)
 		cli.dispatchEvent(&evt)
 	}
 }
@@ -94,10 +97,22 @@ func (cli *Client) SendPresence(ctx context.Context, state types.Presence) error
 // so you should mark yourself as online before trying to use this function:
 //
 //	cli.SendPresence(types.PresenceAvailable)
+//
+// Subscribed users are remembered, and the subscriptions are renewed automatically after reconnecting.
+// The tracked presence state can be queried with IsOnline, LastSeen and GetUserPresence.
 func (cli *Client) SubscribePresence(ctx context.Context, jid types.JID) error {
 	if cli == nil {
 		return ErrClientIsNil
 	}
+	err := cli.sendPresenceSubscription(ctx, jid)
+	if err != nil {
+		return err
+	}
+	cli.presenceTracker.subscribe(jid)
+	return nil
+}
+
+func (cli *Client) sendPresenceSubscription(ctx context.Context, jid types.JID) error {
 	privacyToken, err := cli.Store.PrivacyTokens.GetPrivacyToken(ctx, jid)
 	if err != nil {
 		return fmt.Errorf("failed to get privacy token: %w", err)
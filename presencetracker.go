@@ -0,0 +1,305 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ChatPresenceTimeout is how long a composing chat state is considered valid without a new update.
+// Clients resend the composing state periodically while the user is typing, so older states are most likely stale.
+const ChatPresenceTimeout = 30 * time.Second
+
+// MaxPresenceHistory is the maximum number of online/offline transitions remembered per user.
+const MaxPresenceHistory = 50
+
+// MaxUnsubscribedPresenceUsers is the maximum number of users whose presence is tracked without a subscription,
+// e.g. because they were typing in a group. When the limit is reached, the least recently updated users are forgotten.
+const MaxUnsubscribedPresenceUsers = 1000
+
+// PresenceTransition is a single online/offline change of a user.
+type PresenceTransition struct {
+	Online bool
+	// The time when the change happened. For offline transitions, this is the last seen time sent by the server
+	// if the user hasn't hidden it, otherwise it's the time when the presence update was received.
+	Timestamp time.Time
+}
+
+// UserChatPresence is the typing state of a user in a specific chat.
+type UserChatPresence struct {
+	Chat      types.JID
+	State     types.ChatPresence
+	Media     types.ChatPresenceMedia
+	UpdatedAt time.Time
+}
+
+// UserPresence contains the tracked presence state of a single user.
+type UserPresence struct {
+	JID types.JID
+	// Whether this client is subscribed to the user's presence.
+	Subscribed bool
+	// Whether the user is currently online. This is only accurate if Subscribed is true.
+	// The online state is reset when the client disconnects, as presence updates can't be received while disconnected.
+	Online bool
+	// The last seen time sent by the server. This is zero if the user has hidden it or if it hasn't been received yet.
+	LastSeen  time.Time
+	UpdatedAt time.Time
+	// The typing states of the user in different chats. Stale composing states are reported as paused.
+	ChatPresence []UserChatPresence
+	// The most recent online/offline transitions, oldest first.
+	History []PresenceTransition
+}
+
+type userPresenceState struct {
+	online    bool
+	lastSeen  time.Time
+	updatedAt time.Time
+	touchedAt time.Time
+	chats     map[types.JID]UserChatPresence
+	history   []PresenceTransition
+}
+
+type presenceTracker struct {
+	subscribed map[types.JID]struct{}
+	users      map[types.JID]*userPresenceState
+	lock       sync.RWMutex
+}
+
+func newPresenceTracker() *presenceTracker {
+	return &presenceTracker{
+		subscribed: make(map[types.JID]struct{}),
+		users:      make(map[types.JID]*userPresenceState),
+	}
+}
+
+func (pt *presenceTracker) subscribe(jid types.JID) {
+	pt.lock.Lock()
+	pt.subscribed[jid.ToNonAD()] = struct{}{}
+	pt.lock.Unlock()
+}
+
+func (pt *presenceTracker) unsubscribe(jid types.JID) {
+	pt.lock.Lock()
+	delete(pt.subscribed, jid.ToNonAD())
+	pt.lock.Unlock()
+}
+
+func (pt *presenceTracker) getSubscribed() []types.JID {
+	pt.lock.RLock()
+	defer pt.lock.RUnlock()
+	jids := make([]types.JID, 0, len(pt.subscribed))
+	for jid := range pt.subscribed {
+		jids = append(jids, jid)
+	}
+	return jids
+}
+
+func (pt *presenceTracker) getOrCreateUser(jid types.JID) *userPresenceState {
+	user, ok := pt.users[jid]
+	if !ok {
+		if _, subscribed := pt.subscribed[jid]; !subscribed {
+			pt.pruneUnsubscribed()
+		}
+		user = &userPresenceState{chats: make(map[types.JID]UserChatPresence)}
+		pt.users[jid] = user
+	}
+	user.touchedAt = time.Now()
+	return user
+}
+
+func (pt *presenceTracker) pruneUnsubscribed() {
+	var unsubscribed int
+	var oldestJID types.JID
+	var oldest time.Time
+	for jid, user := range pt.users {
+		if _, subscribed := pt.subscribed[jid]; subscribed {
+			continue
+		}
+		unsubscribed++
+		if oldest.IsZero() || user.touchedAt.Before(oldest) {
+			oldestJID, oldest = jid, user.touchedAt
+		}
+	}
+	if unsubscribed >= MaxUnsubscribedPresenceUsers {
+		delete(pt.users, oldestJID)
+	}
+}
+
+// markDisconnected resets the online and typing states of all users, as they can't be kept up to date while disconnected.
+// The last seen times and histories are kept.
+func (pt *presenceTracker) markDisconnected() {
+	pt.lock.Lock()
+	defer pt.lock.Unlock()
+	for _, user := range pt.users {
+		user.online = false
+		clear(user.chats)
+	}
+}
+
+func (pt *presenceTracker) handlePresence(evt *events.Presence) {
+	jid := evt.From.ToNonAD()
+	now := time.Now()
+	pt.lock.Lock()
+	defer pt.lock.Unlock()
+	user := pt.getOrCreateUser(jid)
+	online := !evt.Unavailable
+	if !evt.LastSeen.IsZero() {
+		user.lastSeen = evt.LastSeen
+	}
+	if online != user.online || len(user.history) == 0 {
+		ts := now
+		if !online && !evt.LastSeen.IsZero() {
+			ts = evt.LastSeen
+		}
+		user.history = append(user.history, PresenceTransition{Online: online, Timestamp: ts})
+		if len(user.history) > MaxPresenceHistory {
+			user.history = slices.Delete(user.history, 0, len(user.history)-MaxPresenceHistory)
+		}
+	}
+	if !online {
+		// Users can't type while offline
+		clear(user.chats)
+	}
+	user.online = online
+	user.updatedAt = now
+}
+
+func (pt *presenceTracker) handleChatPresence(evt *events.ChatPresence) {
+	sender := evt.Sender.ToNonAD()
+	chat := evt.Chat.ToNonAD()
+	pt.lock.Lock()
+	defer pt.lock.Unlock()
+	user := pt.getOrCreateUser(sender)
+	if evt.State == types.ChatPresencePaused {
+		delete(user.chats, chat)
+	} else {
+		user.chats[chat] = UserChatPresence{
+			Chat:      chat,
+			State:     evt.State,
+			Media:     evt.Media,
+			UpdatedAt: time.Now(),
+		}
+	}
+}
+
+func (pt *presenceTracker) get(jid types.JID) *UserPresence {
+	jid = jid.ToNonAD()
+	pt.lock.RLock()
+	defer pt.lock.RUnlock()
+	_, subscribed := pt.subscribed[jid]
+	user, ok := pt.users[jid]
+	if !ok {
+		if !subscribed {
+			return nil
+		}
+		return &UserPresence{JID: jid, Subscribed: true}
+	}
+	out := &UserPresence{
+		JID:          jid,
+		Subscribed:   subscribed,
+		Online:       user.online,
+		LastSeen:     user.lastSeen,
+		UpdatedAt:    user.updatedAt,
+		ChatPresence: make([]UserChatPresence, 0, len(user.chats)),
+		History:      slices.Clone(user.history),
+	}
+	staleCutoff := time.Now().Add(-ChatPresenceTimeout)
+	for _, chatPresence := range user.chats {
+		if chatPresence.UpdatedAt.Before(staleCutoff) {
+			chatPresence.State = types.ChatPresencePaused
+			chatPresence.Media = types.ChatPresenceMediaText
+		}
+		out.ChatPresence = append(out.ChatPresence, chatPresence)
+	}
+	return out
+}
+
+func (cli *Client) resubscribePresence(ctx context.Context) {
+	jids := cli.presenceTracker.getSubscribed()
+	if len(jids) == 0 {
+		return
+	}
+	cli.Log.Debugf("Re-subscribing to presence of %d users after connecting", len(jids))
+	for _, jid := range jids {
+		err := cli.sendPresenceSubscription(ctx, jid)
+		if err != nil {
+			cli.Log.Warnf("Failed to re-subscribe to presence of %s: %v", jid, err)
+		}
+	}
+}
+
+// UnsubscribePresence stops tracking the presence of the given user,
+// so that the subscription won't be automatically renewed when reconnecting.
+//
+// This only affects local state: the server will keep sending presence updates until the connection is closed.
+func (cli *Client) UnsubscribePresence(jid types.JID) {
+	if cli == nil {
+		return
+	}
+	cli.presenceTracker.unsubscribe(jid)
+}
+
+// GetPresenceSubscriptions returns the list of users whose presence this client is subscribed to.
+func (cli *Client) GetPresenceSubscriptions() []types.JID {
+	if cli == nil {
+		return nil
+	}
+	return cli.presenceTracker.getSubscribed()
+}
+
+// GetUserPresence returns the tracked presence state of the given user,
+// or nil if the user isn't subscribed and no presence updates have been received from them.
+func (cli *Client) GetUserPresence(jid types.JID) *UserPresence {
+	if cli == nil {
+		return nil
+	}
+	return cli.presenceTracker.get(jid)
+}
+
+// IsOnline returns true if the given user is currently online according to the presence updates received so far.
+//
+// Presence updates for individual users are only sent after subscribing with SubscribePresence.
+func (cli *Client) IsOnline(jid types.JID) bool {
+	presence := cli.GetUserPresence(jid)
+	return presence != nil && presence.Online
+}
+
+// LastSeen returns the last seen time of the given user. If the user is currently online,
+// the returned time will be the zero value and online will be true.
+//
+// The time will also be zero if the user has hidden their last seen time, or if no presence updates have been received.
+func (cli *Client) LastSeen(jid types.JID) (lastSeen time.Time, online bool) {
+	presence := cli.GetUserPresence(jid)
+	if presence == nil {
+		return
+	} else if presence.Online {
+		return time.Time{}, true
+	}
+	return presence.LastSeen, false
+}
+
+// GetChatPresence returns the current typing state of the given user in the given chat.
+//
+// Composing states that haven't been refreshed within ChatPresenceTimeout are reported as paused.
+func (cli *Client) GetChatPresence(chat, user types.JID) (types.ChatPresence, types.ChatPresenceMedia) {
+	presence := cli.GetUserPresence(user)
+	if presence != nil {
+		chat = chat.ToNonAD()
+		for _, chatPresence := range presence.ChatPresence {
+			if chatPresence.Chat == chat {
+				return chatPresence.State, chatPresence.Media
+			}
+		}
+	}
+	return types.ChatPresencePaused, types.ChatPresenceMediaText
+}
@@ -81,6 +81,9 @@ func (wpc *WebPushConfig) GetPushConfigAttrs() waBinary.Attrs {
 	}
 }
 
+// GetServerPushNotificationConfig gets the push notification settings of the current device from the server.
+//
+// The response can be parsed with push.ParseSettings.
 func (cli *Client) GetServerPushNotificationConfig(ctx context.Context) (*waBinary.Node, error) {
 	resp, err := cli.sendIQ(ctx, infoQuery{
 		Namespace: "urn:xmpp:whatsapp:push",
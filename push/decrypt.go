@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package push
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.mau.fi/whatsmeow/util/gcmutil"
+	"go.mau.fi/whatsmeow/util/hkdfutil"
+)
+
+// Errors that can be returned when decrypting push payloads.
+var (
+	ErrInvalidKeys       = errors.New("invalid push keys")
+	ErrPayloadTooShort   = errors.New("push payload is too short")
+	ErrInvalidRecordSize = errors.New("invalid record size in push payload")
+	ErrInvalidPadding    = errors.New("invalid padding in push payload")
+)
+
+const (
+	saltLength   = 16
+	headerLength = saltLength + 4 + 1
+	tagLength    = 16
+	keyLength    = 16
+	nonceLength  = 12
+)
+
+// Decrypt decrypts a web push payload that was encrypted with the aes128gcm content encoding (RFC 8188 and RFC 8291).
+//
+// The payload is the raw body of the request that the push service delivered.
+func (k *Keys) Decrypt(payload []byte) ([]byte, error) {
+	priv, err := k.privateKey()
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < headerLength {
+		return nil, ErrPayloadTooShort
+	}
+	salt := payload[:saltLength]
+	recordSize := binary.BigEndian.Uint32(payload[saltLength : saltLength+4])
+	keyIDLength := int(payload[saltLength+4])
+	if len(payload) < headerLength+keyIDLength {
+		return nil, ErrPayloadTooShort
+	} else if recordSize <= tagLength+1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidRecordSize, recordSize)
+	}
+	// In web push, the key ID is the application server's ephemeral public key
+	senderPub, err := ecdh.P256().NewPublicKey(payload[headerLength : headerLength+keyIDLength])
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender public key in push payload: %w", err)
+	}
+	ciphertext := payload[headerLength+keyIDLength:]
+	if len(ciphertext) == 0 {
+		return nil, ErrPayloadTooShort
+	}
+
+	sharedSecret, err := priv.ECDH(senderPub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute shared secret: %w", err)
+	}
+	key, nonce := deriveKeys(k.AuthSecret, sharedSecret, priv.PublicKey().Bytes(), senderPub.Bytes(), salt)
+
+	var plaintext []byte
+	for seq := uint64(0); len(ciphertext) > 0; seq++ {
+		record := ciphertext[:min(len(ciphertext), int(recordSize))]
+		ciphertext = ciphertext[len(record):]
+		decrypted, err := gcmutil.Decrypt(key, recordNonce(nonce, seq), record, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt record #%d: %w", seq, err)
+		}
+		decrypted, err = unpadRecord(decrypted, len(ciphertext) == 0)
+		if err != nil {
+			return nil, fmt.Errorf("record #%d: %w", seq, err)
+		}
+		plaintext = append(plaintext, decrypted...)
+	}
+	return plaintext, nil
+}
+
+func deriveKeys(authSecret, sharedSecret, receiverPub, senderPub, salt []byte) (key, nonce []byte) {
+	keyInfo := make([]byte, 0, 14+len(receiverPub)+len(senderPub))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, receiverPub...)
+	keyInfo = append(keyInfo, senderPub...)
+	ikm := hkdfutil.SHA256(sharedSecret, authSecret, keyInfo, 32)
+	key = hkdfutil.SHA256(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), keyLength)
+	nonce = hkdfutil.SHA256(ikm, salt, []byte("Content-Encoding: nonce\x00"), nonceLength)
+	return
+}
+
+func recordNonce(baseNonce []byte, seq uint64) []byte {
+	nonce := bytes.Clone(baseNonce)
+	var seqBytes [8]byte
+	binary.BigEndian.PutUint64(seqBytes[:], seq)
+	for i, b := range seqBytes {
+		nonce[nonceLength-8+i] ^= b
+	}
+	return nonce
+}
+
+// unpadRecord removes the trailing zero padding and the delimiter byte,
+// which is 0x02 for the last record and 0x01 for other records.
+func unpadRecord(data []byte, last bool) ([]byte, error) {
+	end := len(data) - 1
+	for end >= 0 && data[end] == 0 {
+		end--
+	}
+	if end < 0 {
+		return nil, ErrInvalidPadding
+	}
+	expectedDelimiter := byte(0x01)
+	if last {
+		expectedDelimiter = 0x02
+	}
+	if data[end] != expectedDelimiter {
+		return nil, fmt.Errorf("%w: unexpected delimiter 0x%02x", ErrInvalidPadding, data[end])
+	}
+	return data[:end], nil
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package push implements the receiving side of web push notifications for whatsmeow.WebPushConfig registrations.
+//
+// The keys are generated with GenerateKeys and registered using whatsmeow.Client.RegisterForPushNotifications:
+//
+//	keys, err := push.GenerateKeys()
+//	err = cli.RegisterForPushNotifications(ctx, keys.WebPushConfig(endpoint))
+//
+// The payloads that the push service delivers to the endpoint can then be decrypted with Keys.Decrypt
+// and parsed into a Notification with Keys.DecryptNotification. No connection to WhatsApp is needed for decrypting.
+package push
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"fmt"
+
+	"go.mau.fi/util/random"
+
+	"go.mau.fi/whatsmeow"
+)
+
+// AuthSecretLength is the length of the web push authentication secret.
+const AuthSecretLength = 16
+
+// Keys contains the key pair and authentication secret of a web push subscription.
+//
+// The struct can be serialized as JSON to store it between restarts.
+type Keys struct {
+	// The raw P-256 private key.
+	PrivateKey []byte `json:"private_key"`
+	// The uncompressed P-256 public key, which is sent to the server as the p256dh value.
+	PublicKey  []byte `json:"public_key"`
+	AuthSecret []byte `json:"auth_secret"`
+}
+
+// GenerateKeys generates a new P-256 key pair and authentication secret for a web push subscription.
+func GenerateKeys() (*Keys, error) {
+	priv, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key pair: %w", err)
+	}
+	return &Keys{
+		PrivateKey: priv.Bytes(),
+		PublicKey:  priv.PublicKey().Bytes(),
+		AuthSecret: random.Bytes(AuthSecretLength),
+	}, nil
+}
+
+// WebPushConfig returns the config for registering these keys with whatsmeow.Client.RegisterForPushNotifications.
+func (k *Keys) WebPushConfig(endpoint string) *whatsmeow.WebPushConfig {
+	return &whatsmeow.WebPushConfig{
+		Endpoint: endpoint,
+		Auth:     k.AuthSecret,
+		P256DH:   k.PublicKey,
+	}
+}
+
+func (k *Keys) privateKey() (*ecdh.PrivateKey, error) {
+	priv, err := ecdh.P256().NewPrivateKey(k.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeys, err)
+	} else if len(k.AuthSecret) != AuthSecretLength {
+		return nil, fmt.Errorf("%w: auth secret must be %d bytes", ErrInvalidKeys, AuthSecretLength)
+	}
+	return priv, nil
+}
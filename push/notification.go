@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package push
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// NotificationType is the type of event that a push notification is about.
+type NotificationType string
+
+// Known push notification types.
+const (
+	NotificationTypeMessage      NotificationType = "msg"
+	NotificationTypeCall         NotificationType = "call"
+	NotificationTypeNotification NotificationType = "notification"
+)
+
+// Notification is a decrypted WhatsApp web push notification.
+//
+// Push notifications only contain metadata: the actual message must be fetched by connecting to WhatsApp,
+// which delivers it as an offline message.
+type Notification struct {
+	Type      NotificationType
+	ID        string
+	Chat      types.JID
+	Sender    types.JID
+	PushName  string
+	Timestamp time.Time
+
+	// The raw decrypted payload, which may contain fields that aren't parsed into the struct.
+	Raw json.RawMessage
+}
+
+type notificationPayload struct {
+	Type        NotificationType `json:"type"`
+	ID          string           `json:"id"`
+	From        string           `json:"from"`
+	Participant string           `json:"participant"`
+	Notify      string           `json:"notify"`
+	Timestamp   json.RawMessage  `json:"t"`
+}
+
+// ParseNotification parses a decrypted push payload into a Notification.
+func ParseNotification(data []byte) (*Notification, error) {
+	var payload notificationPayload
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse push payload: %w", err)
+	}
+	notif := &Notification{
+		Type:     payload.Type,
+		ID:       payload.ID,
+		PushName: payload.Notify,
+		Raw:      data,
+	}
+	if payload.From != "" {
+		notif.Chat, err = types.ParseJID(payload.From)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse chat in push payload: %w", err)
+		}
+	}
+	if payload.Participant != "" {
+		notif.Sender, err = types.ParseJID(payload.Participant)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sender in push payload: %w", err)
+		}
+	} else {
+		notif.Sender = notif.Chat
+	}
+	if len(payload.Timestamp) > 0 {
+		// The timestamp may be sent as either a number or a string
+		ts, err := strconv.ParseInt(strings.Trim(string(payload.Timestamp), `"`), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse timestamp in push payload: %w", err)
+		} else if ts > 0 {
+			notif.Timestamp = time.Unix(ts, 0)
+		}
+	}
+	return notif, nil
+}
+
+// DecryptNotification decrypts a web push payload and parses it into a Notification.
+func (k *Keys) DecryptNotification(payload []byte) (*Notification, error) {
+	data, err := k.Decrypt(payload)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNotification(data)
+}
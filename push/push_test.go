@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package push
+
+import (
+	"encoding/base64"
+	"testing"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+)
+
+func mustDecode(t *testing.T, data string) []byte {
+	t.Helper()
+	decoded, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("Failed to decode test data: %v", err)
+	}
+	return decoded
+}
+
+// Test vector from RFC 8291 section 5
+func TestKeys_Decrypt(t *testing.T) {
+	keys := &Keys{
+		PrivateKey: mustDecode(t, "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"),
+		PublicKey:  mustDecode(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"),
+		AuthSecret: mustDecode(t, "BTBZMqHH6r4Tts7J_aSIgg"),
+	}
+	payload := mustDecode(t, "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN")
+	plaintext, err := keys.Decrypt(payload)
+	if err != nil {
+		t.Fatalf("Failed to decrypt: %v", err)
+	} else if string(plaintext) != "When I grow up, I want to be a watermelon" {
+		t.Errorf("Unexpected plaintext %q", plaintext)
+	}
+	payload[len(payload)-1] ^= 1
+	if _, err = keys.Decrypt(payload); err == nil {
+		t.Errorf("Decrypting tampered payload didn't fail")
+	}
+}
+
+func TestParseNotification(t *testing.T) {
+	notif, err := ParseNotification([]byte(`{"type":"msg","id":"3EB0ABCDEF","from":"123456789-1234@g.us","participant":"1234567890@s.whatsapp.net","notify":"Tulir","t":"1700000000"}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if notif.Type != NotificationTypeMessage || notif.ID != "3EB0ABCDEF" || notif.PushName != "Tulir" {
+		t.Errorf("Unexpected notification %+v", notif)
+	} else if notif.Chat.String() != "123456789-1234@g.us" || notif.Sender.String() != "1234567890@s.whatsapp.net" {
+		t.Errorf("Unexpected chat or sender %s / %s", notif.Chat, notif.Sender)
+	} else if notif.Timestamp.Unix() != 1700000000 {
+		t.Errorf("Unexpected timestamp %s", notif.Timestamp)
+	}
+}
+
+func TestParseSettings(t *testing.T) {
+	settings, err := ParseSettings(&waBinary.Node{
+		Tag: "iq",
+		Content: []waBinary.Node{{
+			Tag: "settings",
+			Content: []waBinary.Node{{
+				Tag:   "config",
+				Attrs: waBinary.Attrs{"platform": "web", "endpoint": "https://push.example.com/abc", "preview": "1"},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	} else if len(settings.Configs) != 1 {
+		t.Fatalf("Expected 1 config, got %d", len(settings.Configs))
+	}
+	cfg := settings.Configs[0]
+	if cfg.Platform != "web" || cfg.Endpoint != "https://push.example.com/abc" || !cfg.Preview {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package push
+
+import (
+	"context"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	waBinary "go.mau.fi/whatsmeow/binary"
+)
+
+// RegisteredConfig is a push config that the server has stored for the current device.
+type RegisteredConfig struct {
+	Platform string
+	// The push token (for FCM and APNs). Empty for web push.
+	ID string
+	// The push service endpoint (for web push).
+	Endpoint string
+	// Whether message previews are enabled.
+	Preview bool
+
+	// All attributes of the config node, including ones that aren't parsed into the struct.
+	Attrs waBinary.Attrs
+}
+
+// Settings contains the push notification settings returned by whatsmeow.Client.GetServerPushNotificationConfig.
+type Settings struct {
+	Configs []RegisteredConfig
+	// The attributes of the settings node.
+	Attrs waBinary.Attrs
+}
+
+// ParseSettings parses the response of whatsmeow.Client.GetServerPushNotificationConfig.
+//
+// The node can be either the whole response or the settings node inside it.
+func ParseSettings(node *waBinary.Node) (*Settings, error) {
+	if node == nil {
+		return nil, fmt.Errorf("push settings node is nil")
+	}
+	settingsNode := *node
+	if node.Tag != "settings" {
+		var ok bool
+		settingsNode, ok = node.GetOptionalChildByTag("settings")
+		if !ok {
+			return nil, &whatsmeow.ElementMissingError{Tag: "settings", In: "push settings response"}
+		}
+	}
+	settings := &Settings{Attrs: settingsNode.Attrs}
+	for _, child := range settingsNode.GetChildren() {
+		if child.Tag != "config" && child.Tag != "setting" {
+			continue
+		}
+		ag := child.AttrGetter()
+		settings.Configs = append(settings.Configs, RegisteredConfig{
+			Platform: ag.OptionalString("platform"),
+			ID:       ag.OptionalString("id"),
+			Endpoint: ag.OptionalString("endpoint"),
+			Preview:  ag.OptionalBool("preview"),
+			Attrs:    child.Attrs,
+		})
+	}
+	return settings, nil
+}
+
+// FetchSettings gets the push notification settings from the server and parses them.
+func FetchSettings(ctx context.Context, cli *whatsmeow.Client) (*Settings, error) {
+	resp, err := cli.GetServerPushNotificationConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSettings(resp)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// GetQuickReplies returns all business quick replies that have been synced from app state.
+//
+// Quick replies can be created, edited and deleted with SendAppState using appstate.BuildQuickReply
+// and appstate.BuildDeleteQuickReply.
+func (cli *Client) GetQuickReplies(ctx context.Context) ([]*types.QuickReply, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	} else if cli.Store.QuickReplies == nil {
+		return nil, ErrQuickRepliesUnsupported
+	}
+	return cli.Store.QuickReplies.GetQuickReplies(ctx)
+}
+
+// GetQuickReplyByShortcut finds the quick reply with the given shortcut. The leading slash is optional
+// and the comparison is case-insensitive. If there's no such quick reply, this returns nil.
+func (cli *Client) GetQuickReplyByShortcut(ctx context.Context, shortcut string) (*types.QuickReply, error) {
+	replies, err := cli.GetQuickReplies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shortcut = strings.TrimPrefix(shortcut, "/")
+	for _, reply := range replies {
+		if strings.EqualFold(strings.TrimPrefix(reply.Shortcut, "/"), shortcut) {
+			return reply, nil
+		}
+	}
+	return nil, nil
+}
+
+// MatchQuickReplies finds the quick replies that have a keyword appearing in the given text.
+// Keywords are matched case-insensitively against whole words (or sequences of words for multi-word keywords).
+//
+// The results are sorted by usage count, most used first.
+func (cli *Client) MatchQuickReplies(ctx context.Context, text string) ([]*types.QuickReply, error) {
+	replies, err := cli.GetQuickReplies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	normalizedText := " " + normalizeQuickReplyKeyword(text) + " "
+	var matches []*types.QuickReply
+	for _, reply := range replies {
+		for _, keyword := range reply.Keywords {
+			keyword = normalizeQuickReplyKeyword(keyword)
+			if keyword != "" && strings.Contains(normalizedText, " "+keyword+" ") {
+				matches = append(matches, reply)
+				break
+			}
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b *types.QuickReply) int {
+		return int(b.Count - a.Count)
+	})
+	return matches, nil
+}
+
+// normalizeQuickReplyKeyword lowercases the text and replaces runs of non-alphanumeric characters with a single space.
+func normalizeQuickReplyKeyword(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
@@ -34,6 +34,7 @@ func (cli *Client) handleReceipt(ctx context.Context, node *waBinary.Node) {
 				}
 			}()
 		}
+		cli.handleStatusReceipt(receipt)
 		cancelled = cli.dispatchEvent(receipt)
 	}
 }
@@ -54,6 +55,7 @@ func (cli *Client) handleGroupedReceipt(partialReceipt events.Receipt, participa
 			cli.Log.Warnf("Failed to parse user node %s in grouped receipt: %v", child.XMLString(), ag.Error())
 			continue
 		}
+		cli.handleStatusReceipt(&receipt)
 		cli.dispatchEvent(&receipt)
 	}
 }
@@ -160,7 +162,7 @@ func (cli *Client) sendAck(ctx context.Context, node *waBinary.Node, error int)
 		// TODO this hack probably needs to be removed at some point
 		recipientJID, ok := recipient.(types.JID)
 		if ok && recipientJID.Server == types.BotServer && node.Tag == "message" {
-			altRecipient, ok := types.BotJIDMap[recipientJID]
+			altRecipient, ok := types.GetBotPhoneJID(recipientJID)
 			if ok {
 				attrs["recipient"] = altRecipient
 			}
@@ -0,0 +1,363 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/libsignal/ecc"
+	"go.mau.fi/libsignal/fingerprint"
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waFingerprint"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+const (
+	// SecurityCodeVersion is the version of the scannable security code payload.
+	SecurityCodeVersion = 1
+	// securityCodeIterations is the number of hash iterations used for the displayable security code,
+	// same as the numeric fingerprints in the Signal protocol.
+	securityCodeIterations  = 5200
+	securityCodeHashVersion = 0
+)
+
+// SecurityCode contains the security code (also known as safety number) of a chat with another user.
+type SecurityCode struct {
+	// The user whose identity this security code is for.
+	JID types.JID
+	// The 60-digit security code. Use FormattedCode to get the code in the same format that the official apps display.
+	Code string
+	// The payload of the QR code that the other user can scan to verify the code.
+	QRPayload []byte
+	// Whether the other user's current identity has been marked as verified.
+	Verified bool
+}
+
+// FormattedCode returns the security code split into 12 groups of 5 digits, with 4 groups per line.
+func (sc *SecurityCode) FormattedCode() string {
+	var out strings.Builder
+	for i := 0; i+5 <= len(sc.Code); i += 5 {
+		if i > 0 {
+			if i%20 == 0 {
+				out.WriteByte('\n')
+			} else {
+				out.WriteByte(' ')
+			}
+		}
+		out.WriteString(sc.Code[i : i+5])
+	}
+	return out.String()
+}
+
+type securityCodeIdentity struct {
+	PN       types.JID
+	LID      types.JID
+	Identity [32]byte
+}
+
+func (sci *securityCodeIdentity) stableIdentifier() string {
+	if !sci.PN.IsEmpty() {
+		return sci.PN.User
+	}
+	return sci.LID.User
+}
+
+func (sci *securityCodeIdentity) serializedKey() []byte {
+	return append([]byte{ecc.DjbType}, sci.Identity[:]...)
+}
+
+func (sci *securityCodeIdentity) hash() []byte {
+	key := sci.serializedKey()
+	hash := append([]byte{0, securityCodeHashVersion}, key...)
+	hash = append(hash, sci.stableIdentifier()...)
+	hasher := sha512.New()
+	for i := 0; i < securityCodeIterations; i++ {
+		hasher.Reset()
+		hasher.Write(hash)
+		hasher.Write(key)
+		hash = hasher.Sum(hash[:0])
+	}
+	return hash
+}
+
+func (sci *securityCodeIdentity) fingerprintData() *waFingerprint.FingerprintData {
+	data := &waFingerprint.FingerprintData{
+		PublicKey:       sci.serializedKey(),
+		HostedState:     waFingerprint.HostedState_E2EE.Enum(),
+		HashedPublicKey: sci.hash()[:32],
+	}
+	if !sci.PN.IsEmpty() {
+		data.PnIdentifier = []byte(sci.PN.User)
+	}
+	if !sci.LID.IsEmpty() {
+		data.LidIdentifier = []byte(sci.LID.User)
+	}
+	return data
+}
+
+func (sci *securityCodeIdentity) matches(data *waFingerprint.FingerprintData) bool {
+	if subtle.ConstantTimeCompare(data.GetPublicKey(), sci.serializedKey()) != 1 {
+		return false
+	} else if data.PnIdentifier != nil && !sci.PN.IsEmpty() && string(data.PnIdentifier) != sci.PN.User {
+		return false
+	} else if data.LidIdentifier != nil && !sci.LID.IsEmpty() && string(data.LidIdentifier) != sci.LID.User {
+		return false
+	}
+	return true
+}
+
+func (cli *Client) getUserPNAndLID(ctx context.Context, jid types.JID) (pn, lid types.JID, err error) {
+	jid = jid.ToNonAD()
+	if jid.Server == types.HiddenUserServer {
+		lid = jid
+		pn, err = cli.Store.LIDs.GetPNForLID(ctx, jid)
+	} else {
+		pn = jid
+		lid, err = cli.Store.LIDs.GetLIDForPN(ctx, jid)
+	}
+	return
+}
+
+func (cli *Client) getRemoteSecurityCodeIdentity(ctx context.Context, jid types.JID) (*securityCodeIdentity, error) {
+	pn, lid, err := cli.getUserPNAndLID(ctx, jid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alternate JID: %w", err)
+	}
+	out := &securityCodeIdentity{PN: pn, LID: lid}
+	if getter, ok := cli.Store.Identities.(store.IdentityGetter); ok {
+		for _, user := range []types.JID{lid, pn} {
+			if user.IsEmpty() {
+				continue
+			}
+			identity, err := getter.GetIdentity(ctx, user.SignalAddress().String())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get identity of %s: %w", user, err)
+			} else if identity != nil {
+				out.Identity = *identity
+				return out, nil
+			}
+		}
+	}
+	// No session with the primary device yet, ask the server for the identity key
+	target := lid
+	if target.IsEmpty() {
+		target = pn
+	}
+	bundles, err := cli.fetchPreKeys(ctx, []types.JID{target})
+	if err != nil {
+		return nil, err
+	}
+	resp, ok := bundles[target]
+	if !ok {
+		return nil, fmt.Errorf("%w for %s", ErrIdentityKeyNotFound, target)
+	} else if resp.err != nil {
+		return nil, resp.err
+	}
+	out.Identity = resp.bundle.IdentityKey().PublicKey().PublicKey()
+	return out, nil
+}
+
+func (cli *Client) getLocalSecurityCodeIdentity() (*securityCodeIdentity, error) {
+	pn := cli.getOwnID().ToNonAD()
+	if pn.IsEmpty() {
+		return nil, ErrNotLoggedIn
+	}
+	return &securityCodeIdentity{
+		PN:       pn,
+		LID:      cli.getOwnLID().ToNonAD(),
+		Identity: *cli.Store.IdentityKey.Pub,
+	}, nil
+}
+
+// GetSecurityCode computes the security code for the chat with the given user.
+//
+// The code can be compared with the one shown in the official apps,
+// and the QR payload can be rendered as a QR code for the other user to scan.
+func (cli *Client) GetSecurityCode(ctx context.Context, jid types.JID) (*SecurityCode, error) {
+	if cli == nil {
+		return nil, ErrClientIsNil
+	}
+	local, err := cli.getLocalSecurityCodeIdentity()
+	if err != nil {
+		return nil, err
+	}
+	remote, err := cli.getRemoteSecurityCodeIdentity(ctx, jid)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := proto.Marshal(&waFingerprint.CombinedFingerprint{
+		Version:           proto.Uint32(SecurityCodeVersion),
+		LocalFingerprint:  local.fingerprintData(),
+		RemoteFingerprint: remote.fingerprintData(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal security code payload: %w", err)
+	}
+	verified, err := cli.isIdentityVerified(ctx, remote)
+	if err != nil {
+		return nil, err
+	}
+	return &SecurityCode{
+		JID:       jid.ToNonAD(),
+		Code:      fingerprint.NewDisplay(local.hash(), remote.hash()).DisplayText(),
+		QRPayload: payload,
+		Verified:  verified,
+	}, nil
+}
+
+// VerifySecurityCodeQR checks whether the payload of a security code QR scanned from the given user's device
+// matches the current identities. If it does, the user's identity is marked as verified.
+//
+// A mismatch is not an error: it returns false with a nil error. Errors are only returned if the payload is invalid
+// or if the identities couldn't be fetched.
+func (cli *Client) VerifySecurityCodeQR(ctx context.Context, jid types.JID, payload []byte) (bool, error) {
+	if cli == nil {
+		return false, ErrClientIsNil
+	}
+	var scanned waFingerprint.CombinedFingerprint
+	err := proto.Unmarshal(payload, &scanned)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrInvalidSecurityCodePayload, err)
+	} else if scanned.GetVersion() != SecurityCodeVersion {
+		return false, fmt.Errorf("%w %d", ErrSecurityCodeVersion, scanned.GetVersion())
+	} else if scanned.LocalFingerprint == nil || scanned.RemoteFingerprint == nil {
+		return false, fmt.Errorf("%w: missing fingerprint data", ErrInvalidSecurityCodePayload)
+	}
+	local, err := cli.getLocalSecurityCodeIdentity()
+	if err != nil {
+		return false, err
+	}
+	remote, err := cli.getRemoteSecurityCodeIdentity(ctx, jid)
+	if err != nil {
+		return false, err
+	}
+	// The payload was generated on the other user's device, so their local is our remote and vice versa.
+	if !remote.matches(scanned.LocalFingerprint) || !local.matches(scanned.RemoteFingerprint) {
+		return false, nil
+	}
+	return true, cli.putVerifiedIdentity(ctx, remote)
+}
+
+// SetIdentityVerified marks or unmarks the current identity of the given user as verified.
+//
+// This should be used after comparing the security code from GetSecurityCode manually.
+// When scanning a QR code, VerifySecurityCodeQR will mark the identity as verified automatically.
+func (cli *Client) SetIdentityVerified(ctx context.Context, jid types.JID, verified bool) error {
+	if cli == nil {
+		return ErrClientIsNil
+	}
+	if cli.Store.VerifiedIdentities == nil {
+		return ErrVerifiedIdentitiesUnsupported
+	}
+	if !verified {
+		pn, lid, err := cli.getUserPNAndLID(ctx, jid)
+		if err != nil {
+			return fmt.Errorf("failed to get alternate JID: %w", err)
+		}
+		for _, user := range []types.JID{lid, pn} {
+			if !user.IsEmpty() {
+				err = cli.Store.VerifiedIdentities.DeleteVerifiedIdentity(ctx, user)
+				if err != nil {
+					return fmt.Errorf("failed to delete verified identity of %s: %w", user, err)
+				}
+			}
+		}
+		return nil
+	}
+	remote, err := cli.getRemoteSecurityCodeIdentity(ctx, jid)
+	if err != nil {
+		return err
+	}
+	return cli.putVerifiedIdentity(ctx, remote)
+}
+
+// IsIdentityVerified returns true if the current identity of the given user has been marked as verified.
+func (cli *Client) IsIdentityVerified(ctx context.Context, jid types.JID) (bool, error) {
+	if cli == nil {
+		return false, ErrClientIsNil
+	}
+	remote, err := cli.getRemoteSecurityCodeIdentity(ctx, jid)
+	if err != nil {
+		return false, err
+	}
+	return cli.isIdentityVerified(ctx, remote)
+}
+
+func (cli *Client) putVerifiedIdentity(ctx context.Context, identity *securityCodeIdentity) error {
+	if cli.Store.VerifiedIdentities == nil {
+		return ErrVerifiedIdentitiesUnsupported
+	}
+	user := identity.LID
+	if user.IsEmpty() {
+		user = identity.PN
+	}
+	err := cli.Store.VerifiedIdentities.PutVerifiedIdentity(ctx, user, identity.Identity)
+	if err != nil {
+		return fmt.Errorf("failed to store verified identity: %w", err)
+	}
+	return nil
+}
+
+func (cli *Client) isIdentityVerified(ctx context.Context, identity *securityCodeIdentity) (bool, error) {
+	if cli.Store.VerifiedIdentities == nil {
+		return false, ErrVerifiedIdentitiesUnsupported
+	}
+	for _, user := range []types.JID{identity.LID, identity.PN} {
+		if user.IsEmpty() {
+			continue
+		}
+		verified, err := cli.Store.VerifiedIdentities.GetVerifiedIdentity(ctx, user)
+		if err != nil {
+			return false, fmt.Errorf("failed to get verified identity of %s: %w", user, err)
+		} else if verified != nil {
+			return bytes.Equal(verified.Identity[:], identity.Identity[:]), nil
+		}
+	}
+	return false, nil
+}
+
+func (cli *Client) checkVerifiedIdentityChange(ctx context.Context, jid types.JID, ts time.Time, implicit bool) *events.VerifiedIdentityChange {
+	if jid.Device != 0 || cli.Store.VerifiedIdentities == nil {
+		return nil
+	}
+	pn, lid, err := cli.getUserPNAndLID(ctx, jid)
+	if err != nil {
+		cli.Log.Warnf("Failed to get alternate JID of %s to check verified identity: %v", jid, err)
+	}
+	for _, user := range []types.JID{lid, pn} {
+		if user.IsEmpty() {
+			continue
+		}
+		verified, err := cli.Store.VerifiedIdentities.GetVerifiedIdentity(ctx, user)
+		if err != nil {
+			cli.Log.Warnf("Failed to get verified identity of %s: %v", user, err)
+			continue
+		} else if verified == nil {
+			continue
+		}
+		err = cli.Store.VerifiedIdentities.DeleteVerifiedIdentity(ctx, user)
+		if err != nil {
+			cli.Log.Warnf("Failed to delete verified identity of %s after identity change: %v", user, err)
+		}
+		return &events.VerifiedIdentityChange{
+			JID:        jid.ToNonAD(),
+			Timestamp:  ts,
+			VerifiedAt: verified.VerifiedAt,
+			Implicit:   implicit,
+		}
+	}
+	return nil
+}
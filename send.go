@@ -152,6 +152,9 @@ type SendRequestExtra struct {
 	Timeout time.Duration
 	// When sending media to newsletters, the Handle field returned by the file upload.
 	MediaHandle string
+	// When sending to a broadcast JID, the list of users to send the message to.
+	// If nil, the recipients are determined automatically (e.g. from status privacy settings for status broadcasts).
+	BroadcastRecipients []types.JID
 
 	Meta *types.MsgMetaInfo
 	// use this only if you know what you are doing
@@ -194,6 +197,7 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 	} else if len(extra) == 1 {
 		req = extra[0]
 	}
+	cli.markSleepModeActivity()
 	if to.Device > 0 && !req.Peer {
 		err = ErrRecipientADJID
 		return
@@ -313,7 +317,7 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 				extraParams.addressingMode = types.AddressingModePN
 			}
 		} else {
-			groupParticipants, err = cli.getBroadcastListParticipants(ctx, to)
+			groupParticipants, err = cli.getBroadcastListParticipants(ctx, to, req.BroadcastRecipients)
 			if err != nil {
 				err = fmt.Errorf("failed to get broadcast list members: %w", err)
 				return
@@ -388,6 +392,11 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 		} else {
 			cli.Log.Debugf("Stored message secret key for outgoing message %s", req.ID)
 		}
+		cli.trackPollCreation(ctx, &types.MessageInfo{
+			MessageSource: types.MessageSource{Chat: to, Sender: ownID, IsFromMe: true},
+			ID:            req.ID,
+			Timestamp:     time.Now(),
+		}, message)
 	}
 
 	respChan := cli.waitResponse(req.ID)
@@ -396,7 +405,7 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 	switch to.Server {
 	case types.GroupServer, types.BroadcastServer:
 		phash, data, err = cli.sendGroup(ctx, ownID, to, groupParticipants, req.ID, message, &resp.DebugTimings, extraParams)
-	case types.DefaultUserServer, types.BotServer, types.HiddenUserServer:
+	case types.DefaultUserServer, types.BotServer, types.HiddenUserServer, types.InteropServer:
 		if req.Peer {
 			data, err = cli.sendPeerMessage(ctx, to, req.ID, message, &resp.DebugTimings)
 		} else {
@@ -456,7 +465,7 @@ func (cli *Client) SendMessage(ctx context.Context, to types.JID, message *waE2E
 			cli.groupCacheLock.Unlock()
 		case types.BroadcastServer:
 			// TODO do something
-		case types.DefaultUserServer, types.HiddenUserServer, types.BotServer, types.HostedServer, types.HostedLIDServer:
+		case types.DefaultUserServer, types.HiddenUserServer, types.BotServer, types.HostedServer, types.HostedLIDServer, types.InteropServer:
 			cli.userDevicesCacheLock.Lock()
 			delete(cli.userDevicesCache, to)
 			cli.userDevicesCacheLock.Unlock()
@@ -486,18 +495,8 @@ func (cli *Client) RevokeMessage(ctx context.Context, chat types.JID, id types.M
 // BuildMessageKey builds a MessageKey object, which is used to refer to previous messages
 // for things such as replies, revocations and reactions.
 func (cli *Client) BuildMessageKey(chat, sender types.JID, id types.MessageID) *waCommon.MessageKey {
-	key := &waCommon.MessageKey{
-		FromMe:    proto.Bool(true),
-		ID:        proto.String(id),
-		RemoteJID: proto.String(chat.String()),
-	}
-	if !sender.IsEmpty() && sender.User != cli.getOwnID().User && sender.User != cli.getOwnLID().User {
-		key.FromMe = proto.Bool(false)
-		if chat.Server != types.DefaultUserServer && chat.Server != types.HiddenUserServer && chat.Server != types.MessengerServer {
-			key.Participant = proto.String(sender.ToNonAD().String())
-		}
-	}
-	return key
+	fromMe := sender.IsEmpty() || sender.User == cli.getOwnID().User || sender.User == cli.getOwnLID().User
+	return types.NewMessageKey(chat, sender, id, fromMe)
 }
 
 // BuildRevoke builds a message revocation message using the given variables.
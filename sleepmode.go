@@ -0,0 +1,228 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// DefaultSleepIdleTimeout is the default for SleepModeConfig.IdleTimeout.
+const DefaultSleepIdleTimeout = 5 * time.Minute
+
+// DefaultWakeupTimeout is the default for SleepModeConfig.WakeupTimeout.
+const DefaultWakeupTimeout = 2 * time.Minute
+
+// SleepModeConfig contains the settings for EnableSleepMode.
+type SleepModeConfig struct {
+	// The push config to register when enabling sleep mode. If nil, the application must register
+	// for push notifications itself (see RegisterForPushNotifications).
+	Push PushConfig
+	// How long the connection is kept open after the last incoming node or sent message.
+	IdleTimeout time.Duration
+	// How long HandlePushWakeup waits for the server to finish sending offline events.
+	WakeupTimeout time.Duration
+}
+
+type sleepMode struct {
+	config    SleepModeConfig
+	idleTimer *time.Timer
+	sleeping  bool
+	// Non-nil while waking up, closed when the offline sync completes.
+	waking chan struct{}
+}
+
+// EnableSleepMode makes the client disconnect automatically after being idle,
+// and reconnect when the application calls HandlePushWakeup.
+//
+// This allows running many low-traffic sessions without keeping a websocket open for each one.
+// The application is responsible for receiving the push notifications (e.g. using the push package for web push)
+// and calling HandlePushWakeup when one arrives.
+//
+// The client must be connected when enabling sleep mode if config.Push is set.
+func (cli *Client) EnableSleepMode(ctx context.Context, config SleepModeConfig) error {
+	if cli == nil {
+		return ErrClientIsNil
+	}
+	if config.IdleTimeout <= 0 {
+		config.IdleTimeout = DefaultSleepIdleTimeout
+	}
+	if config.WakeupTimeout <= 0 {
+		config.WakeupTimeout = DefaultWakeupTimeout
+	}
+	if config.Push != nil {
+		err := cli.RegisterForPushNotifications(ctx, config.Push)
+		if err != nil {
+			return fmt.Errorf("failed to register for push notifications: %w", err)
+		}
+	}
+	sm := &sleepMode{config: config}
+	sm.idleTimer = time.AfterFunc(config.IdleTimeout, func() {
+		cli.goToSleep(sm)
+	})
+	cli.sleepModeLock.Lock()
+	if cli.sleepMode != nil {
+		cli.sleepMode.stop()
+	}
+	sm.sleeping = !cli.IsConnected()
+	if sm.sleeping {
+		sm.idleTimer.Stop()
+	}
+	cli.sleepMode = sm
+	cli.sleepModeLock.Unlock()
+	return nil
+}
+
+// DisableSleepMode stops disconnecting automatically after being idle.
+//
+// If the client is currently sleeping, it stays disconnected until Connect is called.
+func (cli *Client) DisableSleepMode() {
+	if cli == nil {
+		return
+	}
+	cli.sleepModeLock.Lock()
+	if cli.sleepMode != nil {
+		cli.sleepMode.stop()
+		cli.sleepMode = nil
+	}
+	cli.sleepModeLock.Unlock()
+}
+
+// IsSleeping returns true if sleep mode is enabled and the client has disconnected due to being idle.
+func (cli *Client) IsSleeping() bool {
+	if cli == nil {
+		return false
+	}
+	cli.sleepModeLock.Lock()
+	defer cli.sleepModeLock.Unlock()
+	return cli.sleepMode != nil && cli.sleepMode.sleeping
+}
+
+// HandlePushWakeup should be called by the application when a push notification arrives while sleep mode is enabled.
+//
+// If the client is sleeping, it reconnects and waits until the server has sent all offline events
+// (i.e. until events.OfflineSyncCompleted is emitted) or SleepModeConfig.WakeupTimeout passes.
+// After that, the client goes back to sleep once it's idle again. If the client is already awake,
+// this only resets the idle timer.
+func (cli *Client) HandlePushWakeup(ctx context.Context) error {
+	if cli == nil {
+		return ErrClientIsNil
+	}
+	cli.sleepModeLock.Lock()
+	sm := cli.sleepMode
+	if sm == nil {
+		cli.sleepModeLock.Unlock()
+		return ErrSleepModeNotEnabled
+	} else if sm.waking != nil {
+		waking := sm.waking
+		cli.sleepModeLock.Unlock()
+		select {
+		case <-waking:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else if !sm.sleeping {
+		sm.idleTimer.Reset(sm.config.IdleTimeout)
+		cli.sleepModeLock.Unlock()
+		return nil
+	}
+	waking := make(chan struct{})
+	sm.sleeping = false
+	sm.waking = waking
+	cli.sleepModeLock.Unlock()
+
+	cli.Log.Debugf("Waking up from sleep mode")
+	cli.dispatchEvent(&events.SleepStateChanged{Sleeping: false})
+	err := cli.Connect()
+	if err != nil && !errors.Is(err, ErrAlreadyConnected) {
+		cli.sleepModeLock.Lock()
+		if sm.waking == waking {
+			sm.waking = nil
+			sm.sleeping = true
+			close(waking)
+		}
+		cli.sleepModeLock.Unlock()
+		cli.dispatchEvent(&events.SleepStateChanged{Sleeping: true})
+		return fmt.Errorf("failed to reconnect: %w", err)
+	}
+	select {
+	case <-waking:
+		return nil
+	case <-time.After(sm.config.WakeupTimeout):
+		cli.Log.Warnf("Offline sync didn't complete within %s after waking up", sm.config.WakeupTimeout)
+		cli.finishWakeup(sm)
+		return nil
+	case <-ctx.Done():
+		cli.finishWakeup(sm)
+		return ctx.Err()
+	}
+}
+
+func (sm *sleepMode) stop() {
+	sm.idleTimer.Stop()
+	if sm.waking != nil {
+		close(sm.waking)
+		sm.waking = nil
+	}
+}
+
+func (cli *Client) finishWakeup(sm *sleepMode) {
+	cli.sleepModeLock.Lock()
+	defer cli.sleepModeLock.Unlock()
+	if sm.waking != nil {
+		close(sm.waking)
+		sm.waking = nil
+		sm.idleTimer.Reset(sm.config.IdleTimeout)
+	}
+}
+
+// handleSleepModeOfflineSync is called when the offline sync completes to finish waking up.
+func (cli *Client) handleSleepModeOfflineSync() {
+	cli.sleepModeLock.Lock()
+	sm := cli.sleepMode
+	cli.sleepModeLock.Unlock()
+	if sm != nil {
+		cli.finishWakeup(sm)
+	}
+}
+
+// markSleepModeActivity resets the idle timer of sleep mode.
+func (cli *Client) markSleepModeActivity() {
+	cli.sleepModeLock.Lock()
+	if sm := cli.sleepMode; sm != nil && !sm.sleeping && sm.waking == nil {
+		sm.idleTimer.Reset(sm.config.IdleTimeout)
+	}
+	cli.sleepModeLock.Unlock()
+}
+
+func (cli *Client) goToSleep(sm *sleepMode) {
+	cli.sleepModeLock.Lock()
+	if cli.sleepMode != sm || sm.sleeping || sm.waking != nil {
+		cli.sleepModeLock.Unlock()
+		return
+	}
+	sm.sleeping = true
+	cli.sleepModeLock.Unlock()
+
+	cli.Log.Debugf("Going to sleep after being idle for %s", sm.config.IdleTimeout)
+	if cli.IsLoggedIn() {
+		ctx, cancel := context.WithTimeout(cli.BackgroundEventCtx, 10*time.Second)
+		// Passive devices get push notifications instead of having messages delivered to the socket
+		err := cli.SetPassive(ctx, true)
+		cancel()
+		if err != nil {
+			cli.Log.Warnf("Failed to mark device as passive before sleeping: %v", err)
+		}
+	}
+	cli.Disconnect()
+	cli.dispatchEvent(&events.SleepStateChanged{Sleeping: true})
+}
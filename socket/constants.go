@@ -25,12 +25,35 @@ const (
 
 const (
 	NoiseStartPattern = "Noise_XX_25519_AESGCM_SHA256\x00\x00\x00\x00"
+	// NoiseIKStartPattern is used instead of NoiseStartPattern when the server's static key is already known.
+	NoiseIKStartPattern = "Noise_IK_25519_AESGCM_SHA256\x00\x00\x00\x00"
+	// NoiseXXFallbackPattern is used if the server rejects a Noise_IK handshake and responds with a new static key.
+	NoiseXXFallbackPattern = "Noise_XXfallback_25519_AESGCM_SHA256"
 
 	WAMagicValue = 6
 )
 
 var WAConnHeader = []byte{'W', 'A', WAMagicValue, token.DictVersion}
 
+// EdgeRoutingMagic is the prefix of the edge routing header, which is sent before WAConnHeader
+// when reconnecting with routing info received from the server.
+var EdgeRoutingMagic = []byte{'E', 'D', 0, 1}
+
+// MakeEdgeRoutingHeader creates the edge routing header for the given routing info.
+// The header is not part of the noise prologue, it's only used by the server to route the connection.
+func MakeEdgeRoutingHeader(routingInfo []byte) []byte {
+	if len(routingInfo) == 0 {
+		return nil
+	}
+	header := make([]byte, len(EdgeRoutingMagic)+FrameLengthSize+len(routingInfo))
+	copy(header, EdgeRoutingMagic)
+	header[len(EdgeRoutingMagic)] = byte(len(routingInfo) >> 16)
+	header[len(EdgeRoutingMagic)+1] = byte(len(routingInfo) >> 8)
+	header[len(EdgeRoutingMagic)+2] = byte(len(routingInfo))
+	copy(header[len(EdgeRoutingMagic)+FrameLengthSize:], routingInfo)
+	return header
+}
+
 const (
 	FrameMaxSize    = 1 << 24
 	FrameLengthSize = 3
@@ -34,6 +34,8 @@ type FrameSocket struct {
 	OnDisconnect func(ctx context.Context, remote bool)
 
 	Header []byte
+	// RoutingHeader is sent once before Header. Unlike Header, it's not used as the noise prologue.
+	RoutingHeader []byte
 
 	closed bool
 
@@ -127,13 +129,18 @@ func (fs *FrameSocket) SendFrame(data []byte) error {
 		return fmt.Errorf("%w (got %d bytes, max %d bytes)", ErrFrameTooLarge, len(data), FrameMaxSize)
 	}
 
-	headerLength := len(fs.Header)
-	// Whole frame is header + 3 bytes for length + data
+	routingHeaderLength := len(fs.RoutingHeader)
+	headerLength := routingHeaderLength + len(fs.Header)
+	// Whole frame is routing header + header + 3 bytes for length + data
 	wholeFrame := make([]byte, headerLength+FrameLengthSize+dataLength)
 
-	// Copy the header if it's there
+	// Copy the headers if they're there
+	if fs.RoutingHeader != nil {
+		copy(wholeFrame[:routingHeaderLength], fs.RoutingHeader)
+		fs.RoutingHeader = nil
+	}
 	if fs.Header != nil {
-		copy(wholeFrame[:headerLength], fs.Header)
+		copy(wholeFrame[routingHeaderLength:headerLength], fs.Header)
 		// We only want to send the header once
 		fs.Header = nil
 	}
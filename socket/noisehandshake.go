@@ -75,18 +75,29 @@ func (nh *NoiseHandshake) Decrypt(ciphertext []byte) (plaintext []byte, err erro
 	return
 }
 
+// Split derives the final transport ciphers after the handshake is complete.
+//
+// The first cipher is used by the initiator (client) for writing and the second for reading.
+// The responder uses them the other way around.
+func (nh *NoiseHandshake) Split() (writeKey, readKey cipher.AEAD, err error) {
+	if write, read, err := nh.extractAndExpand(nh.salt, nil); err != nil {
+		return nil, nil, fmt.Errorf("failed to extract final keys: %w", err)
+	} else if writeKey, err = gcmutil.Prepare(write); err != nil {
+		return nil, nil, fmt.Errorf("failed to create final write cipher: %w", err)
+	} else if readKey, err = gcmutil.Prepare(read); err != nil {
+		return nil, nil, fmt.Errorf("failed to create final read cipher: %w", err)
+	}
+	return
+}
+
 func (nh *NoiseHandshake) Finish(
 	ctx context.Context,
 	fs *FrameSocket,
 	frameHandler FrameHandler,
 	disconnectHandler DisconnectHandler,
 ) (*NoiseSocket, error) {
-	if write, read, err := nh.extractAndExpand(nh.salt, nil); err != nil {
-		return nil, fmt.Errorf("failed to extract final keys: %w", err)
-	} else if writeKey, err := gcmutil.Prepare(write); err != nil {
-		return nil, fmt.Errorf("failed to create final write cipher: %w", err)
-	} else if readKey, err := gcmutil.Prepare(read); err != nil {
-		return nil, fmt.Errorf("failed to create final read cipher: %w", err)
+	if writeKey, readKey, err := nh.Split(); err != nil {
+		return nil, err
 	} else if ns, err := newNoiseSocket(ctx, fs, writeKey, readKey, frameHandler, disconnectHandler); err != nil {
 		return nil, fmt.Errorf("failed to create noise socket: %w", err)
 	} else {
@@ -0,0 +1,307 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// StatusLifetime is how long status messages are visible after being posted.
+const StatusLifetime = 24 * time.Hour
+
+// StatusTextStyle contains the styling options for text statuses.
+type StatusTextStyle struct {
+	// The background color in ARGB format, e.g. 0xFF7ACBA5
+	BackgroundARGB uint32
+	// The text color in ARGB format. Defaults to white.
+	TextARGB uint32
+	Font     waE2E.ExtendedTextMessage_FontType
+}
+
+// SendStatusRequest contains the optional parameters for posting a status.
+type SendStatusRequest struct {
+	// The message ID to use. If empty, a random one will be generated.
+	ID types.MessageID
+	// An audience override for this status. If nil, the default status privacy setting from GetStatusPrivacy is used.
+	//
+	// For example, to only send the status to two users:
+	//
+	//	&types.StatusPrivacy{Type: types.StatusPrivacyTypeWhitelist, List: []types.JID{user1, user2}}
+	Audience *types.StatusPrivacy
+}
+
+// StatusViewer contains info about a user who has viewed a status posted by this client.
+type StatusViewer struct {
+	JID       types.JID
+	Timestamp time.Time
+	// Either types.ReceiptTypeRead or types.ReceiptTypePlayed
+	Type types.ReceiptType
+}
+
+// PostedStatus contains info about a status posted by this client.
+type PostedStatus struct {
+	ID        types.MessageID
+	Timestamp time.Time
+	// The users the status was sent to. This is nil for statuses that weren't posted by this Client instance.
+	Audience []types.JID
+	Viewers  []StatusViewer
+}
+
+type postedStatus struct {
+	Timestamp time.Time
+	Audience  []types.JID
+	Viewers   map[types.JID]StatusViewer
+}
+
+type statusTracker struct {
+	statuses map[types.MessageID]*postedStatus
+	lock     sync.RWMutex
+}
+
+func newStatusTracker() *statusTracker {
+	return &statusTracker{statuses: make(map[types.MessageID]*postedStatus)}
+}
+
+func (st *statusTracker) pruneExpired() {
+	cutoff := time.Now().Add(-StatusLifetime)
+	for id, status := range st.statuses {
+		if status.Timestamp.Before(cutoff) {
+			delete(st.statuses, id)
+		}
+	}
+}
+
+func (st *statusTracker) add(id types.MessageID, ts time.Time, audience []types.JID) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	st.pruneExpired()
+	status, ok := st.statuses[id]
+	if !ok {
+		status = &postedStatus{Viewers: make(map[types.JID]StatusViewer)}
+		st.statuses[id] = status
+	}
+	status.Timestamp = ts
+	status.Audience = audience
+}
+
+func (st *statusTracker) remove(id types.MessageID) {
+	st.lock.Lock()
+	delete(st.statuses, id)
+	st.lock.Unlock()
+}
+
+func (st *statusTracker) getAudience(id types.MessageID) []types.JID {
+	st.lock.RLock()
+	defer st.lock.RUnlock()
+	status, ok := st.statuses[id]
+	if !ok {
+		return nil
+	}
+	return status.Audience
+}
+
+func (st *statusTracker) addReceipt(receipt *events.Receipt) {
+	if receipt.Type != types.ReceiptTypeRead && receipt.Type != types.ReceiptTypePlayed {
+		return
+	} else if receipt.Timestamp.Before(time.Now().Add(-StatusLifetime)) {
+		// Receipts for statuses that have already expired aren't interesting
+		return
+	}
+	viewer := receipt.Sender.ToNonAD()
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	st.pruneExpired()
+	for _, id := range receipt.MessageIDs {
+		status, ok := st.statuses[id]
+		if !ok {
+			// The status may have been posted before a restart, so track viewers even if the send wasn't seen.
+			status = &postedStatus{Timestamp: receipt.Timestamp, Viewers: make(map[types.JID]StatusViewer)}
+			st.statuses[id] = status
+		}
+		existing, ok := status.Viewers[viewer]
+		// Played receipts come after read receipts for video statuses, don't downgrade them
+		if ok && existing.Type == types.ReceiptTypePlayed {
+			continue
+		}
+		status.Viewers[viewer] = StatusViewer{
+			JID:       viewer,
+			Timestamp: receipt.Timestamp,
+			Type:      receipt.Type,
+		}
+	}
+}
+
+func (st *statusTracker) get(id types.MessageID) *PostedStatus {
+	st.lock.RLock()
+	defer st.lock.RUnlock()
+	status, ok := st.statuses[id]
+	if !ok {
+		return nil
+	}
+	out := &PostedStatus{
+		ID:        id,
+		Timestamp: status.Timestamp,
+		Audience:  slices.Clone(status.Audience),
+		Viewers:   make([]StatusViewer, 0, len(status.Viewers)),
+	}
+	for _, viewer := range status.Viewers {
+		out.Viewers = append(out.Viewers, viewer)
+	}
+	slices.SortFunc(out.Viewers, func(a, b StatusViewer) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	return out
+}
+
+func (cli *Client) handleStatusReceipt(receipt *events.Receipt) {
+	if receipt.Chat != types.StatusBroadcastJID || receipt.IsFromMe {
+		return
+	}
+	cli.statusTracker.addReceipt(receipt)
+}
+
+// BuildTextStatus builds a text status message with the given background color, text color and font.
+func BuildTextStatus(text string, style StatusTextStyle) *waE2E.Message {
+	textARGB := style.TextARGB
+	if textARGB == 0 {
+		textARGB = 0xFFFFFFFF
+	}
+	return &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text:           proto.String(text),
+			BackgroundArgb: proto.Uint32(style.BackgroundARGB),
+			TextArgb:       proto.Uint32(textARGB),
+			Font:           style.Font.Enum(),
+		},
+	}
+}
+
+// BuildImageStatus builds an image status message from the result of an Upload call.
+func BuildImageStatus(upload UploadResponse, mimetype, caption string, thumbnail []byte) *waE2E.Message {
+	return &waE2E.Message{
+		ImageMessage: &waE2E.ImageMessage{
+			Caption:       proto.String(caption),
+			Mimetype:      proto.String(mimetype),
+			JPEGThumbnail: thumbnail,
+			URL:           proto.String(upload.URL),
+			DirectPath:    proto.String(upload.DirectPath),
+			MediaKey:      upload.MediaKey,
+			FileEncSHA256: upload.FileEncSHA256,
+			FileSHA256:    upload.FileSHA256,
+			FileLength:    proto.Uint64(upload.FileLength),
+		},
+	}
+}
+
+// BuildVideoStatus builds a video status message from the result of an Upload call.
+func BuildVideoStatus(upload UploadResponse, mimetype, caption string, thumbnail []byte, duration time.Duration) *waE2E.Message {
+	return &waE2E.Message{
+		VideoMessage: &waE2E.VideoMessage{
+			Caption:       proto.String(caption),
+			Mimetype:      proto.String(mimetype),
+			JPEGThumbnail: thumbnail,
+			Seconds:       proto.Uint32(uint32(duration.Seconds())),
+			URL:           proto.String(upload.URL),
+			DirectPath:    proto.String(upload.DirectPath),
+			MediaKey:      upload.MediaKey,
+			FileEncSHA256: upload.FileEncSHA256,
+			FileSHA256:    upload.FileSHA256,
+			FileLength:    proto.Uint64(upload.FileLength),
+		},
+	}
+}
+
+// SendStatus posts the given message as a status.
+//
+// The message can be built with BuildTextStatus, BuildImageStatus or BuildVideoStatus,
+// or it can be any other message type that statuses support.
+func (cli *Client) SendStatus(ctx context.Context, message *waE2E.Message, req ...SendStatusRequest) (SendResponse, error) {
+	var opts SendStatusRequest
+	if len(req) > 1 {
+		return SendResponse{}, fmt.Errorf("only one extra parameter may be provided to SendStatus")
+	} else if len(req) == 1 {
+		opts = req[0]
+	}
+	var audience []types.JID
+	var err error
+	if opts.Audience != nil {
+		audience, err = cli.getStatusAudienceRecipients(ctx, *opts.Audience)
+	} else {
+		audience, err = cli.getStatusBroadcastRecipients(ctx)
+	}
+	if err != nil {
+		return SendResponse{}, fmt.Errorf("failed to get status audience: %w", err)
+	} else if audience == nil {
+		audience = []types.JID{}
+	}
+	resp, err := cli.SendMessage(ctx, types.StatusBroadcastJID, message, SendRequestExtra{
+		ID:                  opts.ID,
+		BroadcastRecipients: audience,
+	})
+	if err != nil {
+		return resp, err
+	}
+	cli.statusTracker.add(resp.ID, resp.Timestamp, audience)
+	return resp, nil
+}
+
+// SendTextStatus posts a text status with the given styling.
+func (cli *Client) SendTextStatus(ctx context.Context, text string, style StatusTextStyle, req ...SendStatusRequest) (SendResponse, error) {
+	return cli.SendStatus(ctx, BuildTextStatus(text, style), req...)
+}
+
+// SendImageStatus uploads the given image and posts it as a status.
+func (cli *Client) SendImageStatus(ctx context.Context, data []byte, mimetype, caption string, req ...SendStatusRequest) (SendResponse, error) {
+	upload, err := cli.Upload(ctx, data, MediaImage)
+	if err != nil {
+		return SendResponse{}, fmt.Errorf("failed to upload image: %w", err)
+	}
+	return cli.SendStatus(ctx, BuildImageStatus(upload, mimetype, caption, nil), req...)
+}
+
+// SendVideoStatus uploads the given video and posts it as a status.
+func (cli *Client) SendVideoStatus(ctx context.Context, data []byte, mimetype, caption string, duration time.Duration, req ...SendStatusRequest) (SendResponse, error) {
+	upload, err := cli.Upload(ctx, data, MediaVideo)
+	if err != nil {
+		return SendResponse{}, fmt.Errorf("failed to upload video: %w", err)
+	}
+	return cli.SendStatus(ctx, BuildVideoStatus(upload, mimetype, caption, nil, duration), req...)
+}
+
+// DeleteStatus deletes a status posted earlier.
+//
+// If the status was posted by this Client instance, the revocation is sent to the same audience as the original status.
+// Otherwise, the current default status privacy settings are used to determine the recipients.
+func (cli *Client) DeleteStatus(ctx context.Context, id types.MessageID) (SendResponse, error) {
+	audience := cli.statusTracker.getAudience(id)
+	resp, err := cli.SendMessage(ctx, types.StatusBroadcastJID, cli.BuildRevoke(types.StatusBroadcastJID, types.EmptyJID, id), SendRequestExtra{
+		BroadcastRecipients: audience,
+	})
+	if err != nil {
+		return resp, err
+	}
+	cli.statusTracker.remove(id)
+	return resp, nil
+}
+
+// GetStatusViewers returns the info of a status posted in the past 24 hours, including the list of users who have viewed it.
+//
+// Viewers are collected from read receipts in memory, so receipts that arrived before the client was started are not included.
+// If no info is known about the status, this returns nil.
+func (cli *Client) GetStatusViewers(id types.MessageID) *PostedStatus {
+	return cli.statusTracker.get(id)
+}
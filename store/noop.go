@@ -26,28 +26,51 @@ var NoopDevice = &Device{
 	NoiseKey:    nilKey,
 	IdentityKey: nilKey,
 
-	Identities:    nilStore,
-	Sessions:      nilStore,
-	PreKeys:       nilStore,
-	SenderKeys:    nilStore,
-	AppStateKeys:  nilStore,
-	AppState:      nilStore,
-	Contacts:      nilStore,
-	ChatSettings:  nilStore,
-	MsgSecrets:    nilStore,
-	PrivacyTokens: nilStore,
-	EventBuffer:   nilStore,
-	LIDs:          nilStore,
-	Container:     nilStore,
+	Identities:         nilStore,
+	VerifiedIdentities: nilStore,
+	Sessions:           nilStore,
+	PreKeys:            nilStore,
+	SenderKeys:         nilStore,
+	AppStateKeys:       nilStore,
+	AppState:           nilStore,
+	Contacts:           nilStore,
+	ChatSettings:       nilStore,
+	BroadcastLists:     nilStore,
+	QuickReplies:       nilStore,
+	Groups:             nilStore,
+	Bots:               nilStore,
+	Polls:              nilStore,
+	MsgSecrets:         nilStore,
+	PrivacyTokens:      nilStore,
+	EventBuffer:        nilStore,
+	LIDs:               nilStore,
+	Container:          nilStore,
 }
 
 var _ AllStores = (*NoopStore)(nil)
 var _ DeviceContainer = (*NoopStore)(nil)
+var _ IdentityGetter = (*NoopStore)(nil)
 
 func (n *NoopStore) PutIdentity(ctx context.Context, address string, key [32]byte) error {
 	return n.Error
 }
 
+func (n *NoopStore) GetIdentity(ctx context.Context, address string) (*[32]byte, error) {
+	return nil, n.Error
+}
+
+func (n *NoopStore) PutVerifiedIdentity(ctx context.Context, user types.JID, identity [32]byte) error {
+	return n.Error
+}
+
+func (n *NoopStore) DeleteVerifiedIdentity(ctx context.Context, user types.JID) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetVerifiedIdentity(ctx context.Context, user types.JID) (*VerifiedIdentity, error) {
+	return nil, n.Error
+}
+
 func (n *NoopStore) DeleteAllIdentities(ctx context.Context, phone string) error {
 	return n.Error
 }
@@ -208,6 +231,70 @@ func (n *NoopStore) GetChatSettings(ctx context.Context, chat types.JID) (types.
 	return types.LocalChatSettings{}, n.Error
 }
 
+func (n *NoopStore) PutBroadcastList(ctx context.Context, list types.BroadcastList) error {
+	return n.Error
+}
+
+func (n *NoopStore) DeleteBroadcastList(ctx context.Context, jid types.JID) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetBroadcastList(ctx context.Context, jid types.JID) (*types.BroadcastList, error) {
+	return nil, n.Error
+}
+
+func (n *NoopStore) GetAllBroadcastLists(ctx context.Context) ([]*types.BroadcastList, error) {
+	return nil, n.Error
+}
+
+func (n *NoopStore) PutQuickReply(ctx context.Context, reply types.QuickReply) error {
+	return n.Error
+}
+
+func (n *NoopStore) DeleteQuickReply(ctx context.Context, id string) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetQuickReplies(ctx context.Context) ([]*types.QuickReply, error) {
+	return nil, n.Error
+}
+
+func (n *NoopStore) PutGroup(ctx context.Context, info *types.GroupInfo, fetchedAt time.Time) error {
+	return n.Error
+}
+
+func (n *NoopStore) DeleteGroup(ctx context.Context, jid types.JID) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetGroup(ctx context.Context, jid types.JID) (*types.GroupInfo, time.Time, error) {
+	return nil, time.Time{}, n.Error
+}
+
+func (n *NoopStore) PutBots(ctx context.Context, bots []types.BotListInfo) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetBots(ctx context.Context) ([]types.BotListInfo, time.Time, error) {
+	return nil, time.Time{}, n.Error
+}
+
+func (n *NoopStore) PutPoll(ctx context.Context, poll *types.PollInfo) error {
+	return n.Error
+}
+
+func (n *NoopStore) GetPoll(ctx context.Context, chat types.JID, id types.MessageID) (*types.PollInfo, error) {
+	return nil, n.Error
+}
+
+func (n *NoopStore) PutPollVote(ctx context.Context, chat types.JID, id types.MessageID, vote types.PollVote) (bool, error) {
+	return false, n.Error
+}
+
+func (n *NoopStore) GetPollVotes(ctx context.Context, chat types.JID, id types.MessageID) ([]types.PollVote, error) {
+	return nil, n.Error
+}
+
 func (n *NoopStore) PutMessageSecrets(ctx context.Context, inserts []MessageSecretInsert) error {
 	return n.Error
 }
@@ -127,7 +127,8 @@ const getAllDevicesQuery = `
 SELECT jid, lid, registration_id, noise_key, identity_key,
        signed_pre_key, signed_pre_key_id, signed_pre_key_sig,
        adv_key, adv_details, adv_account_sig, adv_account_sig_key, adv_device_sig,
-       platform, business_name, push_name, facebook_uuid, lid_migration_ts, external_id, namespace
+       platform, business_name, push_name, facebook_uuid, lid_migration_ts, external_id, namespace,
+       edge_routing_info, server_static_key
 FROM whatsmeow_device
 `
 
@@ -147,7 +148,8 @@ func (c *Container) scanDevice(row dbutil.Scannable) (*store.Device, error) {
 		&preKeyPriv, &device.SignedPreKey.KeyID, &preKeySig,
 		&device.AdvSecretKey, &account.Details, &account.AccountSignature, &account.AccountSignatureKey, &account.DeviceSignature,
 		&device.Platform, &device.BusinessName, &device.PushName, &fbUUID, &device.LIDMigrationTimestamp,
-		&device.ExternalID, &device.Namespace,
+		&device.ExternalID, &device.Namespace, &device.EdgeRoutingInfo,
+		&device.ServerStaticKey,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan session: %w", err)
@@ -244,14 +246,17 @@ const (
 		INSERT INTO whatsmeow_device (jid, lid, registration_id, noise_key, identity_key,
 									  signed_pre_key, signed_pre_key_id, signed_pre_key_sig,
 									  adv_key, adv_details, adv_account_sig, adv_account_sig_key, adv_device_sig,
-									  platform, business_name, push_name, facebook_uuid, lid_migration_ts, external_id, namespace)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+									  platform, business_name, push_name, facebook_uuid, lid_migration_ts, external_id, namespace,
+									  edge_routing_info, server_static_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 		ON CONFLICT (jid) DO UPDATE
 			SET lid=excluded.lid,
 				platform=excluded.platform,
 				business_name=excluded.business_name,
 				push_name=excluded.push_name,
-				lid_migration_ts=excluded.lid_migration_ts
+				lid_migration_ts=excluded.lid_migration_ts,
+				edge_routing_info=excluded.edge_routing_info,
+				server_static_key=excluded.server_static_key
 	`
 
 	updateDeviceNameSpaces = `UPDATE whatsmeow_device SET namespace=$1 WHERE namespace=$2`
@@ -358,6 +363,8 @@ func (c *Container) PutDevice(ctx context.Context, device *store.Device) error {
 		device.LIDMigrationTimestamp,
 		device.ExternalID,
 		device.Namespace,
+		device.EdgeRoutingInfo,
+		device.ServerStaticKey,
 	)
 
 	if !device.Initialized {
@@ -369,6 +376,7 @@ func (c *Container) PutDevice(ctx context.Context, device *store.Device) error {
 func (c *Container) initializeDevice(device *store.Device) {
 	innerStore := NewSQLStore(c, *device.ID)
 	device.Identities = innerStore
+	device.VerifiedIdentities = innerStore
 	device.Sessions = innerStore
 	device.PreKeys = innerStore
 	device.SenderKeys = innerStore
@@ -376,6 +384,11 @@ func (c *Container) initializeDevice(device *store.Device) {
 	device.AppState = innerStore
 	device.Contacts = innerStore
 	device.ChatSettings = innerStore
+	device.BroadcastLists = innerStore
+	device.QuickReplies = innerStore
+	device.Groups = innerStore
+	device.Bots = innerStore
+	device.Polls = innerStore
 	device.MsgSecrets = innerStore
 	device.PrivacyTokens = innerStore
 	device.EventBuffer = innerStore
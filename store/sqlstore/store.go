@@ -108,6 +108,55 @@ func (s *SQLStore) IsTrustedIdentity(ctx context.Context, address string, key [3
 	return *(*[32]byte)(existingIdentity) == key, nil
 }
 
+func (s *SQLStore) GetIdentity(ctx context.Context, address string) (*[32]byte, error) {
+	var identity []byte
+	err := s.db.QueryRow(ctx, getIdentityQuery, s.JID, address).Scan(&identity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(identity) != 32 {
+		return nil, ErrInvalidLength
+	}
+	return (*[32]byte)(identity), nil
+}
+
+const (
+	putVerifiedIdentityQuery = `
+		INSERT INTO whatsmeow_verified_identities (our_jid, their_jid, identity, verified_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (our_jid, their_jid) DO UPDATE SET identity=excluded.identity, verified_at=excluded.verified_at
+	`
+	deleteVerifiedIdentityQuery = `DELETE FROM whatsmeow_verified_identities WHERE our_jid=$1 AND their_jid=$2`
+	getVerifiedIdentityQuery    = `SELECT identity, verified_at FROM whatsmeow_verified_identities WHERE our_jid=$1 AND their_jid=$2`
+)
+
+func (s *SQLStore) PutVerifiedIdentity(ctx context.Context, user types.JID, identity [32]byte) error {
+	_, err := s.db.Exec(ctx, putVerifiedIdentityQuery, s.JID, user.ToNonAD(), identity[:], time.Now().Unix())
+	return err
+}
+
+func (s *SQLStore) DeleteVerifiedIdentity(ctx context.Context, user types.JID) error {
+	_, err := s.db.Exec(ctx, deleteVerifiedIdentityQuery, s.JID, user.ToNonAD())
+	return err
+}
+
+func (s *SQLStore) GetVerifiedIdentity(ctx context.Context, user types.JID) (*store.VerifiedIdentity, error) {
+	verified := store.VerifiedIdentity{User: user.ToNonAD()}
+	var identity []byte
+	var verifiedAt int64
+	err := s.db.QueryRow(ctx, getVerifiedIdentityQuery, s.JID, verified.User).Scan(&identity, &verifiedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else if len(identity) != 32 {
+		return nil, ErrInvalidLength
+	}
+	verified.Identity = *(*[32]byte)(identity)
+	verified.VerifiedAt = time.Unix(verifiedAt, 0)
+	return &verified, nil
+}
+
 const (
 	getSessionQuery             = `SELECT session FROM whatsmeow_sessions WHERE our_jid=$1 AND their_id=$2`
 	hasSessionQuery             = `SELECT true FROM whatsmeow_sessions WHERE our_jid=$1 AND their_id=$2`
@@ -872,6 +921,273 @@ func (s *SQLStore) GetChatSettings(ctx context.Context, chat types.JID) (setting
 	return
 }
 
+const (
+	putBroadcastListQuery = `
+		INSERT INTO whatsmeow_broadcast_lists (our_jid, list_jid, name, recipients, label_ids) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (our_jid, list_jid) DO UPDATE SET name=excluded.name, recipients=excluded.recipients, label_ids=excluded.label_ids
+	`
+	deleteBroadcastListQuery = `
+		DELETE FROM whatsmeow_broadcast_lists WHERE our_jid=$1 AND list_jid=$2
+	`
+	getBroadcastListQuery = `
+		SELECT list_jid, name, recipients, label_ids FROM whatsmeow_broadcast_lists WHERE our_jid=$1 AND list_jid=$2
+	`
+	getAllBroadcastListsQuery = `
+		SELECT list_jid, name, recipients, label_ids FROM whatsmeow_broadcast_lists WHERE our_jid=$1
+	`
+)
+
+func (s *SQLStore) PutBroadcastList(ctx context.Context, list types.BroadcastList) error {
+	if list.Recipients == nil {
+		list.Recipients = []types.BroadcastRecipient{}
+	}
+	if list.LabelIDs == nil {
+		list.LabelIDs = []string{}
+	}
+	_, err := s.db.Exec(
+		ctx, putBroadcastListQuery, s.JID, list.JID, list.Name,
+		dbutil.JSON{Data: list.Recipients}, dbutil.JSON{Data: list.LabelIDs},
+	)
+	return err
+}
+
+func (s *SQLStore) DeleteBroadcastList(ctx context.Context, jid types.JID) error {
+	_, err := s.db.Exec(ctx, deleteBroadcastListQuery, s.JID, jid)
+	return err
+}
+
+func scanBroadcastList(row dbutil.Scannable) (*types.BroadcastList, error) {
+	var list types.BroadcastList
+	err := row.Scan(&list.JID, &list.Name, dbutil.JSON{Data: &list.Recipients}, dbutil.JSON{Data: &list.LabelIDs})
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+func (s *SQLStore) GetBroadcastList(ctx context.Context, jid types.JID) (*types.BroadcastList, error) {
+	list, err := scanBroadcastList(s.db.QueryRow(ctx, getBroadcastListQuery, s.JID, jid))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return list, err
+}
+
+func (s *SQLStore) GetAllBroadcastLists(ctx context.Context) ([]*types.BroadcastList, error) {
+	rows, err := s.db.Query(ctx, getAllBroadcastListsQuery, s.JID)
+	return dbutil.NewRowIterWithError(rows, scanBroadcastList, err).AsList()
+}
+
+const (
+	putQuickReplyQuery = `
+		INSERT INTO whatsmeow_quick_replies (our_jid, reply_id, shortcut, message, keywords, count) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (our_jid, reply_id) DO UPDATE
+			SET shortcut=excluded.shortcut, message=excluded.message, keywords=excluded.keywords, count=excluded.count
+	`
+	deleteQuickReplyQuery = `
+		DELETE FROM whatsmeow_quick_replies WHERE our_jid=$1 AND reply_id=$2
+	`
+	getQuickRepliesQuery = `
+		SELECT reply_id, shortcut, message, keywords, count FROM whatsmeow_quick_replies WHERE our_jid=$1
+	`
+)
+
+func (s *SQLStore) PutQuickReply(ctx context.Context, reply types.QuickReply) error {
+	if reply.Keywords == nil {
+		reply.Keywords = []string{}
+	}
+	_, err := s.db.Exec(
+		ctx, putQuickReplyQuery, s.JID, reply.ID, reply.Shortcut, reply.Message,
+		dbutil.JSON{Data: reply.Keywords}, reply.Count,
+	)
+	return err
+}
+
+func (s *SQLStore) DeleteQuickReply(ctx context.Context, id string) error {
+	_, err := s.db.Exec(ctx, deleteQuickReplyQuery, s.JID, id)
+	return err
+}
+
+func scanQuickReply(row dbutil.Scannable) (*types.QuickReply, error) {
+	var reply types.QuickReply
+	err := row.Scan(&reply.ID, &reply.Shortcut, &reply.Message, dbutil.JSON{Data: &reply.Keywords}, &reply.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &reply, nil
+}
+
+func (s *SQLStore) GetQuickReplies(ctx context.Context) ([]*types.QuickReply, error) {
+	rows, err := s.db.Query(ctx, getQuickRepliesQuery, s.JID)
+	return dbutil.NewRowIterWithError(rows, scanQuickReply, err).AsList()
+}
+
+const (
+	putGroupQuery = `
+		INSERT INTO whatsmeow_groups (our_jid, group_jid, participant_version_id, info, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (our_jid, group_jid) DO UPDATE
+			SET participant_version_id=excluded.participant_version_id, info=excluded.info, updated_at=excluded.updated_at
+	`
+	deleteGroupQuery = `
+		DELETE FROM whatsmeow_groups WHERE our_jid=$1 AND group_jid=$2
+	`
+	getGroupQuery = `
+		SELECT info, updated_at FROM whatsmeow_groups WHERE our_jid=$1 AND group_jid=$2
+	`
+)
+
+func (s *SQLStore) PutGroup(ctx context.Context, info *types.GroupInfo, fetchedAt time.Time) error {
+	_, err := s.db.Exec(
+		ctx, putGroupQuery, s.JID, info.JID, info.ParticipantVersionID, dbutil.JSON{Data: info}, fetchedAt.Unix(),
+	)
+	return err
+}
+
+func (s *SQLStore) DeleteGroup(ctx context.Context, jid types.JID) error {
+	_, err := s.db.Exec(ctx, deleteGroupQuery, s.JID, jid)
+	return err
+}
+
+func (s *SQLStore) GetGroup(ctx context.Context, jid types.JID) (*types.GroupInfo, time.Time, error) {
+	var info types.GroupInfo
+	var fetchedAt int64
+	err := s.db.QueryRow(ctx, getGroupQuery, s.JID, jid).Scan(dbutil.JSON{Data: &info}, &fetchedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, time.Time{}, nil
+	} else if err != nil {
+		return nil, time.Time{}, err
+	}
+	return &info, time.Unix(fetchedAt, 0), nil
+}
+
+const (
+	deleteAllBotsQuery = `
+		DELETE FROM whatsmeow_bots WHERE our_jid=$1
+	`
+	putBotQuery = `
+		INSERT INTO whatsmeow_bots (our_jid, bot_jid, pn_jid, persona_id, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (our_jid, bot_jid) DO UPDATE
+			SET pn_jid=excluded.pn_jid, persona_id=excluded.persona_id, updated_at=excluded.updated_at
+	`
+	getBotsQuery = `
+		SELECT bot_jid, pn_jid, persona_id, updated_at FROM whatsmeow_bots WHERE our_jid=$1
+	`
+)
+
+func (s *SQLStore) PutBots(ctx context.Context, bots []types.BotListInfo) error {
+	now := time.Now().Unix()
+	return s.db.DoTxn(ctx, nil, func(ctx context.Context) error {
+		_, err := s.db.Exec(ctx, deleteAllBotsQuery, s.JID)
+		if err != nil {
+			return err
+		}
+		for _, bot := range bots {
+			_, err = s.db.Exec(ctx, putBotQuery, s.JID, bot.BotJID, bot.PhoneJID, bot.PersonaID, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *SQLStore) GetBots(ctx context.Context) (bots []types.BotListInfo, updatedAt time.Time, err error) {
+	rows, err := s.db.Query(ctx, getBotsQuery, s.JID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var bot types.BotListInfo
+		var ts int64
+		err = rows.Scan(&bot.BotJID, &bot.PhoneJID, &bot.PersonaID, &ts)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if rowUpdatedAt := time.Unix(ts, 0); updatedAt.IsZero() || rowUpdatedAt.Before(updatedAt) {
+			updatedAt = rowUpdatedAt
+		}
+		bots = append(bots, bot)
+	}
+	return bots, updatedAt, rows.Err()
+}
+
+const (
+	putPollQuery = `
+		INSERT INTO whatsmeow_polls (our_jid, chat_jid, poll_id, creator_jid, name, options, selectable_count, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (our_jid, chat_jid, poll_id) DO UPDATE
+			SET name=excluded.name, options=excluded.options, selectable_count=excluded.selectable_count
+	`
+	getPollQuery = `
+		SELECT creator_jid, name, options, selectable_count, created_at
+		FROM whatsmeow_polls WHERE our_jid=$1 AND chat_jid=$2 AND poll_id=$3
+	`
+	putPollVoteQuery = `
+		INSERT INTO whatsmeow_poll_votes (our_jid, chat_jid, poll_id, voter_jid, selected, timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (our_jid, chat_jid, poll_id, voter_jid) DO UPDATE
+			SET selected=excluded.selected, timestamp=excluded.timestamp
+			WHERE excluded.timestamp >= whatsmeow_poll_votes.timestamp
+	`
+	getPollVotesQuery = `
+		SELECT voter_jid, selected, timestamp FROM whatsmeow_poll_votes WHERE our_jid=$1 AND chat_jid=$2 AND poll_id=$3
+	`
+)
+
+func (s *SQLStore) PutPoll(ctx context.Context, poll *types.PollInfo) error {
+	_, err := s.db.Exec(
+		ctx, putPollQuery, s.JID, poll.Chat, poll.ID, poll.Creator, poll.Name, dbutil.JSON{Data: poll.Options},
+		poll.SelectableCount, poll.CreatedAt.Unix(),
+	)
+	return err
+}
+
+func (s *SQLStore) GetPoll(ctx context.Context, chat types.JID, id types.MessageID) (*types.PollInfo, error) {
+	poll := types.PollInfo{Chat: chat, ID: id}
+	var createdAt int64
+	err := s.db.QueryRow(ctx, getPollQuery, s.JID, chat, id).
+		Scan(&poll.Creator, &poll.Name, dbutil.JSON{Data: &poll.Options}, &poll.SelectableCount, &createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	poll.CreatedAt = time.Unix(createdAt, 0)
+	return &poll, nil
+}
+
+func (s *SQLStore) PutPollVote(ctx context.Context, chat types.JID, id types.MessageID, vote types.PollVote) (bool, error) {
+	res, err := s.db.Exec(
+		ctx, putPollVoteQuery, s.JID, chat, id, vote.Voter, dbutil.JSON{Data: vote.SelectedHashes}, vote.Timestamp.UnixMilli(),
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	return affected > 0, err
+}
+
+func (s *SQLStore) GetPollVotes(ctx context.Context, chat types.JID, id types.MessageID) ([]types.PollVote, error) {
+	rows, err := s.db.Query(ctx, getPollVotesQuery, s.JID, chat, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var votes []types.PollVote
+	for rows.Next() {
+		var vote types.PollVote
+		var ts int64
+		err = rows.Scan(&vote.Voter, dbutil.JSON{Data: &vote.SelectedHashes}, &ts)
+		if err != nil {
+			return nil, err
+		}
+		vote.Timestamp = time.UnixMilli(ts)
+		votes = append(votes, vote)
+	}
+	return votes, rows.Err()
+}
+
 const (
 	putMsgSecret = `
 		INSERT INTO whatsmeow_message_secrets (our_jid, chat_jid, sender_jid, message_id, key)
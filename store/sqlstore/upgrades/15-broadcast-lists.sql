@@ -0,0 +1,11 @@
+-- v15 (compatible with v8+): Add table for broadcast lists synced from app state
+CREATE TABLE whatsmeow_broadcast_lists (
+	our_jid    TEXT,
+	list_jid   TEXT,
+	name       TEXT NOT NULL DEFAULT '',
+	recipients TEXT NOT NULL,
+	label_ids  TEXT NOT NULL,
+
+	PRIMARY KEY (our_jid, list_jid),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,10 @@
+-- v16 (compatible with v8+): Add table for identities verified with security codes
+CREATE TABLE whatsmeow_verified_identities (
+	our_jid     TEXT,
+	their_jid   TEXT,
+	identity    bytea  NOT NULL CHECK ( length(identity) = 32 ),
+	verified_at BIGINT NOT NULL,
+
+	PRIMARY KEY (our_jid, their_jid),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
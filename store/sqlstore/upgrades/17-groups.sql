@@ -0,0 +1,11 @@
+-- v17 (compatible with v8+): Add table for cached group metadata
+CREATE TABLE whatsmeow_groups (
+	our_jid                TEXT,
+	group_jid              TEXT,
+	participant_version_id TEXT NOT NULL DEFAULT '',
+	info                   TEXT NOT NULL,
+	updated_at             BIGINT NOT NULL,
+
+	PRIMARY KEY (our_jid, group_jid),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
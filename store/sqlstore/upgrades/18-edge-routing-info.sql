@@ -0,0 +1,2 @@
+-- v18 (compatible with v8+): Add edge routing info column to device table
+ALTER TABLE whatsmeow_device ADD COLUMN edge_routing_info bytea;
@@ -0,0 +1,11 @@
+-- v19 (compatible with v8+): Add table for the bot registry
+CREATE TABLE whatsmeow_bots (
+	our_jid    TEXT,
+	bot_jid    TEXT,
+	pn_jid     TEXT,
+	persona_id TEXT NOT NULL DEFAULT '',
+	updated_at BIGINT NOT NULL,
+
+	PRIMARY KEY (our_jid, bot_jid),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,26 @@
+-- v20 (compatible with v8+): Add tables for tracking polls and their votes
+CREATE TABLE whatsmeow_polls (
+	our_jid          TEXT,
+	chat_jid         TEXT,
+	poll_id          TEXT,
+	creator_jid      TEXT    NOT NULL,
+	name             TEXT    NOT NULL,
+	options          TEXT    NOT NULL,
+	selectable_count INTEGER NOT NULL,
+	created_at       BIGINT  NOT NULL,
+
+	PRIMARY KEY (our_jid, chat_jid, poll_id),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE whatsmeow_poll_votes (
+	our_jid   TEXT,
+	chat_jid  TEXT,
+	poll_id   TEXT,
+	voter_jid TEXT,
+	selected  TEXT   NOT NULL,
+	timestamp BIGINT NOT NULL,
+
+	PRIMARY KEY (our_jid, chat_jid, poll_id, voter_jid),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,12 @@
+-- v21 (compatible with v8+): Add table for business quick replies synced from app state
+CREATE TABLE whatsmeow_quick_replies (
+	our_jid  TEXT,
+	reply_id TEXT,
+	shortcut TEXT    NOT NULL,
+	message  TEXT    NOT NULL,
+	keywords TEXT    NOT NULL,
+	count    INTEGER NOT NULL DEFAULT 0,
+
+	PRIMARY KEY (our_jid, reply_id),
+	FOREIGN KEY (our_jid) REFERENCES whatsmeow_device(jid) ON DELETE CASCADE ON UPDATE CASCADE
+);
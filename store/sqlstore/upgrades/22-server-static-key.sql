@@ -0,0 +1,2 @@
+-- v22 (compatible with v8+): Add cached noise server static key column to device table
+ALTER TABLE whatsmeow_device ADD COLUMN server_static_key bytea;
@@ -26,6 +26,24 @@ type IdentityStore interface {
 	IsTrustedIdentity(ctx context.Context, address string, key [32]byte) (bool, error)
 }
 
+// IdentityGetter is an optional interface that an IdentityStore can implement to allow reading stored identity keys.
+// If it's not implemented, identity keys are fetched from the server when generating security codes.
+type IdentityGetter interface {
+	GetIdentity(ctx context.Context, address string) (*[32]byte, error)
+}
+
+type VerifiedIdentity struct {
+	User       types.JID
+	Identity   [32]byte
+	VerifiedAt time.Time
+}
+
+type VerifiedIdentityStore interface {
+	PutVerifiedIdentity(ctx context.Context, user types.JID, identity [32]byte) error
+	DeleteVerifiedIdentity(ctx context.Context, user types.JID) error
+	GetVerifiedIdentity(ctx context.Context, user types.JID) (*VerifiedIdentity, error)
+}
+
 type SessionStore interface {
 	GetSession(ctx context.Context, address string) ([]byte, error)
 	HasSession(ctx context.Context, address string) (bool, error)
@@ -117,6 +135,44 @@ type ChatSettingsStore interface {
 	GetChatSettings(ctx context.Context, chat types.JID) (types.LocalChatSettings, error)
 }
 
+type BroadcastListStore interface {
+	PutBroadcastList(ctx context.Context, list types.BroadcastList) error
+	DeleteBroadcastList(ctx context.Context, jid types.JID) error
+	GetBroadcastList(ctx context.Context, jid types.JID) (*types.BroadcastList, error)
+	GetAllBroadcastLists(ctx context.Context) ([]*types.BroadcastList, error)
+}
+
+type QuickReplyStore interface {
+	PutQuickReply(ctx context.Context, reply types.QuickReply) error
+	DeleteQuickReply(ctx context.Context, id string) error
+	GetQuickReplies(ctx context.Context) ([]*types.QuickReply, error)
+}
+
+type GroupStore interface {
+	// PutGroup stores the given group info. The fetchedAt time is when the info was last fetched from the server,
+	// which stays the same when the stored info is updated based on notifications.
+	PutGroup(ctx context.Context, info *types.GroupInfo, fetchedAt time.Time) error
+	DeleteGroup(ctx context.Context, jid types.JID) error
+	// GetGroup returns the stored group info and the time when it was last fetched from the server.
+	GetGroup(ctx context.Context, jid types.JID) (*types.GroupInfo, time.Time, error)
+}
+
+type BotStore interface {
+	// PutBots replaces the stored bot list with the given list.
+	PutBots(ctx context.Context, bots []types.BotListInfo) error
+	// GetBots returns the stored bot list and the time when it was stored.
+	GetBots(ctx context.Context) ([]types.BotListInfo, time.Time, error)
+}
+
+type PollStore interface {
+	PutPoll(ctx context.Context, poll *types.PollInfo) error
+	GetPoll(ctx context.Context, chat types.JID, id types.MessageID) (*types.PollInfo, error)
+	// PutPollVote stores the vote, unless the stored vote of the same user is newer.
+	// The returned bool is false if the vote wasn't stored because a newer one exists.
+	PutPollVote(ctx context.Context, chat types.JID, id types.MessageID, vote types.PollVote) (bool, error)
+	GetPollVotes(ctx context.Context, chat types.JID, id types.MessageID) ([]types.PollVote, error)
+}
+
 type DeviceContainer interface {
 	PutDevice(ctx context.Context, store *Device) error
 	DeleteDevice(ctx context.Context, store *Device) error
@@ -183,6 +239,7 @@ type LIDStore interface {
 
 type AllSessionSpecificStores interface {
 	IdentityStore
+	VerifiedIdentityStore
 	SessionStore
 	PreKeyStore
 	SenderKeyStore
@@ -190,6 +247,11 @@ type AllSessionSpecificStores interface {
 	AppStateStore
 	ContactStore
 	ChatSettingsStore
+	BroadcastListStore
+	QuickReplyStore
+	GroupStore
+	BotStore
+	PollStore
 	MsgSecretStore
 	PrivacyTokenStore
 	EventBuffer
@@ -225,20 +287,33 @@ type Device struct {
 
 	FacebookUUID uuid.UUID
 
-	Initialized   bool
-	Identities    IdentityStore
-	Sessions      SessionStore
-	PreKeys       PreKeyStore
-	SenderKeys    SenderKeyStore
-	AppStateKeys  AppStateSyncKeyStore
-	AppState      AppStateStore
-	Contacts      ContactStore
-	ChatSettings  ChatSettingsStore
-	MsgSecrets    MsgSecretStore
-	PrivacyTokens PrivacyTokenStore
-	EventBuffer   EventBuffer
-	LIDs          LIDStore
-	Container     DeviceContainer
+	// EdgeRoutingInfo is the routing info last received from the server,
+	// which is sent in the handshake to reconnect to the same edge server.
+	EdgeRoutingInfo []byte
+	// ServerStaticKey is the noise static key of the server, which was verified using the certificate chain
+	// in the last full handshake. It's used to resume connections with the Noise_IK handshake.
+	ServerStaticKey []byte
+
+	Initialized        bool
+	Identities         IdentityStore
+	VerifiedIdentities VerifiedIdentityStore
+	Sessions           SessionStore
+	PreKeys            PreKeyStore
+	SenderKeys         SenderKeyStore
+	AppStateKeys       AppStateSyncKeyStore
+	AppState           AppStateStore
+	Contacts           ContactStore
+	ChatSettings       ChatSettingsStore
+	BroadcastLists     BroadcastListStore
+	QuickReplies       QuickReplyStore
+	Groups             GroupStore
+	Bots               BotStore
+	Polls              PollStore
+	MsgSecrets         MsgSecretStore
+	PrivacyTokens      PrivacyTokenStore
+	EventBuffer        EventBuffer
+	LIDs               LIDStore
+	Container          DeviceContainer
 
 	ExternalID string
 	Namespace  string
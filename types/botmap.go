@@ -1,5 +1,13 @@
 package types
 
+import (
+	"sync"
+)
+
+// BotJIDMap is the static map from bot JIDs to their phone number JIDs.
+//
+// It's only used as a fallback for bots that aren't in the dynamic registry (see RegisterBots).
+// Use GetBotPhoneJID to look up bots instead of reading this map directly.
 var BotJIDMap = map[JID]JID{
 	NewJID("867051314767696", BotServer):   NewJID("13135550002", DefaultUserServer),
 	NewJID("1061492271844689", BotServer):  NewJID("13135550005", DefaultUserServer),
@@ -208,3 +216,63 @@ var BotJIDMap = map[JID]JID{
 	NewJID("1175736513679463", BotServer):  NewJID("13135559120", DefaultUserServer),
 	NewJID("491811473512352", BotServer):   NewJID("13165550064", DefaultUserServer),
 }
+
+var (
+	botRegistry       map[JID]JID
+	botRegistryPhones map[JID]struct{}
+	botRegistryLock   sync.RWMutex
+)
+
+// RegisterBots adds the given bots to the dynamic bot registry.
+//
+// This is called automatically by whatsmeow when the bot list is fetched from the server,
+// so bots added by WhatsApp are addressed correctly without updating the static BotJIDMap.
+// The registry is shared by all clients in the process, so bots are only added, never removed:
+// a list fetched by one client doesn't drop bots that another client has seen.
+//
+// Only bots with a PhoneJID get a phone number mapping. Bots without one are remembered,
+// but GetBotPhoneJID falls back to the static BotJIDMap for them.
+func RegisterBots(bots []BotListInfo) {
+	botRegistryLock.Lock()
+	defer botRegistryLock.Unlock()
+	if botRegistry == nil {
+		botRegistry = make(map[JID]JID, len(bots))
+		botRegistryPhones = make(map[JID]struct{}, len(bots))
+	}
+	for _, bot := range bots {
+		if bot.BotJID.IsEmpty() {
+			continue
+		}
+		botJID := bot.BotJID.ToNonAD()
+		if bot.PhoneJID.IsEmpty() {
+			// Don't overwrite a known phone number with an empty one
+			if _, ok := botRegistry[botJID]; !ok {
+				botRegistry[botJID] = EmptyJID
+			}
+			continue
+		}
+		botRegistry[botJID] = bot.PhoneJID.ToNonAD()
+		botRegistryPhones[bot.PhoneJID.ToNonAD()] = struct{}{}
+	}
+}
+
+// GetBotPhoneJID returns the phone number JID of the given bot JID,
+// first checking the dynamic registry and then falling back to the static BotJIDMap.
+func GetBotPhoneJID(bot JID) (JID, bool) {
+	bot = bot.ToNonAD()
+	botRegistryLock.RLock()
+	phoneJID, ok := botRegistry[bot]
+	botRegistryLock.RUnlock()
+	if ok && !phoneJID.IsEmpty() {
+		return phoneJID, true
+	}
+	phoneJID, ok = BotJIDMap[bot]
+	return phoneJID, ok
+}
+
+func isRegisteredBotPhone(jid JID) bool {
+	botRegistryLock.RLock()
+	_, ok := botRegistryPhones[jid]
+	botRegistryLock.RUnlock()
+	return ok
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package types
+
+// QuickReply contains info about a WhatsApp Business quick reply that was synced from app state.
+type QuickReply struct {
+	ID string
+	// The shortcut that is typed after a slash to insert the quick reply, e.g. "thanks" for /thanks.
+	Shortcut string
+	Message  string
+	// Keywords that suggest the quick reply when they appear in a message.
+	Keywords []string
+	// How many times the quick reply has been used.
+	Count int32
+}
+
+// ProductImage is an image of a product in a business catalog.
+type ProductImage struct {
+	// The URL of the image. When creating or editing products, this must be a URL that WhatsApp servers can fetch.
+	URL string
+	// The URL of the original full-size image. This is only set in products fetched from the server.
+	OriginalURL string
+}
+
+// Product is a product in a business catalog.
+type Product struct {
+	// The ID assigned by WhatsApp. This is empty when creating products.
+	ID string
+	// An optional ID assigned by the business.
+	RetailerID  string
+	Name        string
+	Description string
+	URL         string
+	// The price multiplied by 1000 (i.e. 1.50 is 1500), like in waE2E.ProductMessage_ProductSnapshot.
+	PriceAmount1000 int64
+	// ISO 4217 currency code.
+	Currency string
+	Hidden   bool
+	Images   []ProductImage
+	// ISO 3166-1 alpha-2 code of the country of origin.
+	OriginCountryCode string
+
+	// The review status, e.g. "APPROVED" or "PENDING". Only set in products fetched from the server.
+	ReviewStatus string
+	// The availability, e.g. "in stock". Only set in products fetched from the server.
+	Availability string
+}
+
+// Catalog is a page of products in a business catalog.
+type Catalog struct {
+	Products []*Product
+	// The cursor for the next page, or empty if there are no more products.
+	NextCursor string
+}
+
+// ProductCollection is a named group of products in a business catalog.
+type ProductCollection struct {
+	// The ID assigned by WhatsApp. This is empty when creating collections.
+	ID   string
+	Name string
+	// The products in the collection. When creating or editing collections, only the product IDs are used.
+	Products []*Product
+
+	// The review status, e.g. "APPROVED" or "PENDING". Only set in collections fetched from the server.
+	ReviewStatus string
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/proto/waChatLockSettings"
 	"go.mau.fi/whatsmeow/proto/waSyncAction"
 	"go.mau.fi/whatsmeow/types"
 )
@@ -145,6 +146,25 @@ type UserStatusMute struct {
 	FromFullSync bool                               // Whether the action is emitted because of a fullSync
 }
 
+// ChatLock is emitted when a chat is locked or unlocked from another device.
+type ChatLock struct {
+	JID       types.JID // The chat which was locked or unlocked.
+	Timestamp time.Time // The time when the (un)locking happened.
+
+	Action       *waSyncAction.LockChatAction // Whether the chat is now locked or not.
+	FromFullSync bool                         // Whether the action is emitted because of a fullSync
+}
+
+// ChatLockSettings is emitted when the chat lock settings are changed from another device.
+//
+// The secret code can be checked with whatsmeow.VerifyChatLockSecretCode.
+type ChatLockSettings struct {
+	Timestamp time.Time // The time when the setting was changed.
+
+	Action       *waChatLockSettings.ChatLockSettings // The new settings.
+	FromFullSync bool                                 // Whether the action is emitted because of a fullSync
+}
+
 // LabelEdit is emitted when a label is edited from any device.
 type LabelEdit struct {
 	Timestamp time.Time // The time when the label was edited.
@@ -154,6 +174,15 @@ type LabelEdit struct {
 	FromFullSync bool                          // Whether the action is emitted because of a fullSync
 }
 
+// BroadcastList is emitted when a broadcast list is created, edited or deleted from another device.
+type BroadcastList struct {
+	JID       types.JID // The broadcast list which was modified.
+	Timestamp time.Time // The time when the modification happened.
+
+	Action       *waSyncAction.BusinessBroadcastListAction // The new broadcast list info.
+	FromFullSync bool                                      // Whether the action is emitted because of a fullSync
+}
+
 // LabelAssociationChat is emitted when a chat is labeled or unlabeled from any device.
 type LabelAssociationChat struct {
 	JID       types.JID // The chat which was labeled or unlabeled.
@@ -175,6 +204,51 @@ type LabelAssociationMessage struct {
 	FromFullSync bool                                 // Whether the action is emitted because of a fullSync
 }
 
+// LabelReordering is emitted when the order of labels is changed from any device.
+type LabelReordering struct {
+	Timestamp time.Time // The time when the labels were reordered.
+
+	Action       *waSyncAction.LabelReorderingAction // The new order of label IDs.
+	FromFullSync bool                                // Whether the action is emitted because of a fullSync
+}
+
+// QuickReply is emitted when a business quick reply is created, edited or deleted from any device.
+type QuickReply struct {
+	ID        string    // The ID of the quick reply.
+	Timestamp time.Time // The time when the quick reply was modified.
+
+	Action       *waSyncAction.QuickReplyAction // The new quick reply info.
+	FromFullSync bool                           // Whether the action is emitted because of a fullSync
+}
+
+// DeviceAgent is emitted when an agent is added, renamed or removed in a multi-agent business account.
+type DeviceAgent struct {
+	AgentID   string    // The ID of the agent.
+	Timestamp time.Time // The time when the agent was modified.
+
+	Action       *waSyncAction.AgentAction // The new agent info.
+	FromFullSync bool                      // Whether the action is emitted because of a fullSync
+}
+
+// ChatAssignment is emitted when a chat is assigned to an agent (or unassigned) in a multi-agent business account.
+type ChatAssignment struct {
+	JID       types.JID // The chat which was assigned.
+	Timestamp time.Time // The time when the assignment happened.
+
+	Action       *waSyncAction.ChatAssignmentAction // The assigned agent. An empty agent ID means the chat was unassigned.
+	FromFullSync bool                               // Whether the action is emitted because of a fullSync
+}
+
+// ChatAssignmentOpenedStatus is emitted when an agent opens an assigned chat in a multi-agent business account.
+type ChatAssignmentOpenedStatus struct {
+	JID       types.JID // The chat which was opened.
+	AgentID   string    // The agent who the chat is assigned to.
+	Timestamp time.Time // The time when the status changed.
+
+	Action       *waSyncAction.ChatAssignmentOpenedStatusAction // Whether the chat has been opened.
+	FromFullSync bool                                           // Whether the action is emitted because of a fullSync
+}
+
 // AppState is emitted directly for new data received from app state syncing.
 // You should generally use the higher-level events like events.Contact and events.Mute.
 type AppState struct {
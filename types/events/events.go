@@ -12,6 +12,8 @@ import (
 	"strconv"
 	"time"
 
+	"go.mau.fi/util/jsontime"
+
 	waBinary "go.mau.fi/whatsmeow/binary"
 	armadillo "go.mau.fi/whatsmeow/proto"
 	"go.mau.fi/whatsmeow/proto/instamadilloTransportPayload"
@@ -241,11 +243,60 @@ type StreamError struct {
 // Disconnected is emitted when the websocket is closed by the server.
 type Disconnected struct{}
 
+// SleepStateChanged is emitted when the client goes to sleep or wakes up in sleep mode (see Client.EnableSleepMode).
+//
+// Unlike Disconnected, going to sleep is expected, so the client will not reconnect automatically.
+type SleepStateChanged struct {
+	Sleeping bool
+}
+
 // HistorySync is emitted when the phone has sent a blob of historical messages.
 type HistorySync struct {
 	Data *waHistorySync.HistorySync
 }
 
+// GroupHistory is emitted when another group member has shared recent group history with this user,
+// usually right after this user was added to the group.
+type GroupHistory struct {
+	Info   types.MessageInfo // Information about the message that contained the history bundle.
+	Bundle *waE2E.MessageHistoryBundle
+
+	Messages []*Message // The messages in the bundle, parsed the same way as history sync messages.
+}
+
+// PollResultsChanged is emitted when a vote to a tracked poll is received and the results of the poll change.
+//
+// Polls are tracked automatically when their creation message is received or sent, unless Client.DisablePollTracking is set.
+type PollResultsChanged struct {
+	Info    types.MessageInfo // Information about the vote message.
+	Voter   types.JID
+	Results *types.PollResults
+}
+
+// BotResponseUpdated is emitted when a new chunk of a streamed bot response is received.
+//
+// The individual chunks are also emitted as normal Message events. This event contains the whole response
+// assembled so far, so it's usually more convenient to use than the raw chunks.
+type BotResponseUpdated struct {
+	Info       types.MessageInfo // Information about the chunk that caused this update.
+	PromptID   types.MessageID   // The ID of the message that the bot is responding to.
+	ResponseID types.MessageID   // The ID of the first chunk, which the other chunks edit.
+	EditType   types.BotEditType
+
+	Text    string         // The full text of the response so far.
+	Message *waE2E.Message // The latest content of the response.
+}
+
+// BotResponseCompleted is emitted when the last chunk of a bot response is received.
+type BotResponseCompleted struct {
+	Info       types.MessageInfo // Information about the last chunk.
+	PromptID   types.MessageID
+	ResponseID types.MessageID
+
+	Text    string
+	Message *waE2E.Message
+}
+
 type DecryptFailMode string
 
 const (
@@ -472,6 +523,11 @@ type GroupInfo struct {
 	Ephemeral *types.GroupEphemeral // Disappearing messages change
 
 	MembershipApprovalMode *types.GroupMembershipApprovalMode // Membership approval mode change
+	MemberLinkMode         *types.GroupMemberLinkMode         // Who can see and share the invite link
+
+	ParticipantLabelEnabled *bool // Whether participants can set labels for themselves
+	SafetyCheck             *bool // Whether the group safety overview is shown to new members
+	Hidden                  *bool // Whether the group is hidden from the community group list
 
 	Delete *types.GroupDelete
 
@@ -523,6 +579,18 @@ type IdentityChange struct {
 	Implicit bool
 }
 
+// VerifiedIdentityChange is emitted in addition to IdentityChange when the user whose identity changed
+// had been verified by scanning or comparing the security code. The verification is removed when this happens,
+// so the new security code must be verified again.
+type VerifiedIdentityChange struct {
+	JID        types.JID
+	Timestamp  time.Time
+	VerifiedAt time.Time // The time when the previous identity was verified.
+
+	// Implicit is copied from the corresponding IdentityChange event.
+	Implicit bool
+}
+
 // PrivacySettings is emitted when the user changes their privacy settings.
 type PrivacySettings struct {
 	NewSettings         types.PrivacySettings
@@ -616,6 +684,57 @@ type NewsletterMuteChange struct {
 	Mute types.NewsletterMuteState `json:"mute"`
 }
 
+// CommunityParticipantRolesChange is emitted when the community-wide roles of participants are changed,
+// e.g. when community admins are promoted or demoted, or when the community owner changes.
+type CommunityParticipantRolesChange struct {
+	ID          types.JID                `json:"id"`
+	UpdatedBy   *types.GroupUpdateAuthor `json:"updated_by"`
+	UpdateTime  jsontime.UnixString      `json:"update_time"`
+	RoleUpdates []types.GroupRoleUpdate  `json:"role_updates"`
+}
+
+// GroupPropertyChange is emitted when a property of a group or community that isn't included in normal
+// group notifications is changed.
+//
+// If the change includes properties that are also present in GroupInfo (e.g. the member link mode),
+// a GroupInfo event with those fields is emitted right after this event.
+type GroupPropertyChange struct {
+	ID         types.JID                `json:"id"`
+	UpdatedBy  *types.GroupUpdateAuthor `json:"updated_by"`
+	UpdateTime jsontime.UnixString      `json:"update_time"`
+	Properties GroupProperties          `json:"properties"`
+}
+
+// GroupProperties contains the properties that were changed in a GroupPropertyChange event.
+// Fields are nil if the property wasn't changed.
+type GroupProperties struct {
+	AllowNonAdminSubGroupCreation *bool                      `json:"allow_non_admin_sub_group_creation,omitempty"`
+	MemberLinkMode                *types.GroupMemberLinkMode `json:"member_link_mode,omitempty"`
+	ParticipantLabelEnabled       *bool                      `json:"participant_label_enabled,omitempty"`
+	SafetyCheck                   *bool                      `json:"group_safety_check,omitempty"`
+	Hidden                        *bool                      `json:"hidden_group,omitempty"`
+}
+
+// GroupParticipantLabelChange is emitted when a group participant changes their own label in a group.
+type GroupParticipantLabelChange struct {
+	UpdatedBy  *types.GroupUpdateAuthor `json:"updated_by"`
+	UpdateTime jsontime.UnixString      `json:"update_time"`
+	Update     struct {
+		GroupID types.JID                    `json:"group_jid"`
+		Label   *types.GroupParticipantLabel `json:"participant_label"`
+	} `json:"participant_property_update"`
+}
+
+// InteropGroupCreate is emitted when the user is added to a new group that includes users of third-party messengers.
+type InteropGroupCreate struct {
+	Group types.InteropGroupInfo `json:"group"`
+}
+
+// InteropGroupParticipantsChange is emitted when participants are added to an interop group.
+type InteropGroupParticipantsChange struct {
+	Group types.InteropGroupInfo `json:"group"`
+}
+
 type NewsletterLiveUpdate struct {
 	JID      types.JID
 	Time     time.Time
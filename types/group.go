@@ -7,7 +7,10 @@
 package types
 
 import (
+	"bytes"
 	"time"
+
+	"go.mau.fi/util/jsontime"
 )
 
 type GroupMemberAddMode string
@@ -17,6 +20,23 @@ const (
 	GroupMemberAddModeAllMember GroupMemberAddMode = "all_member_add"
 )
 
+// GroupMemberLinkMode specifies who can see and share the invite link of a group.
+type GroupMemberLinkMode string
+
+func (mode *GroupMemberLinkMode) UnmarshalText(text []byte) error {
+	*mode = GroupMemberLinkMode(bytes.ToLower(text))
+	return nil
+}
+
+func (mode GroupMemberLinkMode) MarshalText() ([]byte, error) {
+	return bytes.ToUpper([]byte(mode)), nil
+}
+
+const (
+	GroupMemberLinkModeAdmin     GroupMemberLinkMode = "admin_link"
+	GroupMemberLinkModeAllMember GroupMemberLinkMode = "all_member_link"
+)
+
 // GroupInfo contains basic information about a group chat on WhatsApp.
 type GroupInfo struct {
 	JID      JID
@@ -43,7 +63,15 @@ type GroupInfo struct {
 	Participants         []GroupParticipant
 	ParticipantCount     int
 
-	MemberAddMode GroupMemberAddMode
+	MemberAddMode  GroupMemberAddMode
+	MemberLinkMode GroupMemberLinkMode
+
+	// Whether participants can set a label that is shown next to their name in the group.
+	IsParticipantLabelEnabled bool
+	// Whether WhatsApp shows a safety overview of the group to new members.
+	IsSafetyCheckEnabled bool
+	// Whether the group is hidden from the community group list.
+	IsHidden bool
 
 	// Suspended indicates whether the group is currently paused/suspended.
 	Suspended bool
@@ -113,6 +141,10 @@ type GroupParticipant struct {
 	// This is only present for anonymous users in announcement groups, it's an obfuscated phone number
 	DisplayName string
 
+	// The label the participant has set for themselves in this group, if participant labels are enabled.
+	Label      string
+	LabelSetAt time.Time
+
 	// When creating groups, adding some participants may fail.
 	// In such cases, the error code will be here.
 	Error      int
@@ -166,3 +198,62 @@ type GroupParticipantRequest struct {
 	JID         JID
 	RequestedAt time.Time
 }
+
+// GroupParticipantRole is the role of a participant in a group or community, as used in GraphQL queries and notifications.
+type GroupParticipantRole string
+
+func (gpr *GroupParticipantRole) UnmarshalText(text []byte) error {
+	*gpr = GroupParticipantRole(bytes.ToLower(text))
+	return nil
+}
+
+func (gpr GroupParticipantRole) MarshalText() ([]byte, error) {
+	return bytes.ToUpper([]byte(gpr)), nil
+}
+
+const (
+	GroupParticipantRoleMember     GroupParticipantRole = "member"
+	GroupParticipantRoleAdmin      GroupParticipantRole = "admin"
+	GroupParticipantRoleSuperAdmin GroupParticipantRole = "superadmin"
+)
+
+// GroupUpdateAuthor contains info about the user who made a change that was sent as a GraphQL notification.
+type GroupUpdateAuthor struct {
+	JID        JID    `json:"id"`
+	PN         JID    `json:"pn"`
+	NotifyName string `json:"notify_name"`
+}
+
+// GroupParticipantLabel contains the label of a group participant, as used in GraphQL queries and notifications.
+type GroupParticipantLabel struct {
+	Label        string        `json:"label"`
+	LastModified jsontime.Unix `json:"last_mtime_in_sec"`
+}
+
+// GroupRoleUpdateUser contains the alternate identifiers of the user in a GroupRoleUpdate.
+type GroupRoleUpdateUser struct {
+	JID JID `json:"jid"`
+	PN  JID `json:"pn"`
+}
+
+// GroupRoleUpdate contains a single role change of a group or community participant.
+type GroupRoleUpdate struct {
+	User    JID                  `json:"user_jid"`
+	NewRole GroupParticipantRole `json:"new_role"`
+
+	// Only present in notifications
+	UserInfo *GroupRoleUpdateUser `json:"user,omitempty"`
+}
+
+// SubGroupSuggestion contains info about a group that a community member has suggested to be added to the community.
+type SubGroupSuggestion struct {
+	JID              JID
+	Name             string
+	Topic            string
+	Creator          JID
+	CreatedAt        time.Time
+	ParticipantCount int
+	// True if the suggested group already exists, false if it would be created when the suggestion is approved.
+	IsExistingGroup bool
+	IsHidden        bool
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package types
+
+import (
+	"go.mau.fi/util/jsontime"
+)
+
+// InteropParticipant is a member of an interop group. The JID may be a normal WhatsApp user or a user of a third-party messenger.
+type InteropParticipant struct {
+	JID         JID    `json:"jid"`
+	DisplayName string `json:"display_name"`
+}
+
+// InteropParticipantResponse contains the result of adding a single participant to an interop group.
+type InteropParticipantResponse struct {
+	JID JID `json:"jid"`
+	// The error code if adding the participant failed. Empty on success.
+	ResponseCode string `json:"response_code,omitempty"`
+}
+
+// InteropGroupSubject contains the name of an interop group.
+type InteropGroupSubject struct {
+	Value string              `json:"value"`
+	SetBy *InteropParticipant `json:"creator_v2,omitempty"`
+	SetAt jsontime.UnixString `json:"creation_time,omitempty"`
+}
+
+// InteropGroupInfo contains info about a group that includes users of third-party messengers.
+type InteropGroupInfo struct {
+	GID          string               `json:"gid"`
+	CreationTime jsontime.UnixString  `json:"creation_time"`
+	Creator      *InteropParticipant  `json:"creator_v2,omitempty"`
+	Subject      *InteropGroupSubject `json:"subject,omitempty"`
+	Participants []InteropParticipant `json:"participants_v2"`
+}
+
+// JID returns the chat JID of the interop group, which can be used to send messages to it.
+func (igi *InteropGroupInfo) JID() JID {
+	return NewJID(igi.GID, GroupServer)
+}
@@ -110,9 +110,13 @@ func (jid JID) SignalAddress() *signalProtocol.SignalAddress {
 
 func (jid JID) SignalAddressUser() string {
 	user := jid.User
+	if jid.Integrator > 0 && jid.Server == InteropServer {
+		// Users of different integrators may have the same user part, so the integrator is needed to keep sessions separate
+		user = fmt.Sprintf("%d-%s", jid.Integrator, jid.User)
+	}
 	agent := jid.ActualAgent()
 	if agent != 0 {
-		user = fmt.Sprintf("%s_%d", jid.User, agent)
+		user = fmt.Sprintf("%s_%d", user, agent)
 	}
 	return user
 }
@@ -124,8 +128,22 @@ func (jid JID) IsBroadcastList() bool {
 
 var botUserRegex = regexp.MustCompile(`^1313555\d{4}$|^131655500\d{2}$`)
 
+// IsInterop returns true if the JID is a user of a third-party messenger.
+func (jid JID) IsInterop() bool {
+	return jid.Server == InteropServer
+}
+
+// IsBot returns true if the JID is a bot, either a bot server JID or the phone number JID of a bot.
+//
+// Phone number JIDs are also checked against the process-wide bot registry (see RegisterBots). The registry is
+// mutable and never shrinks, so the result for a given JID can change from false to true after a bot list is fetched.
 func (jid JID) IsBot() bool {
-	return (jid.Server == DefaultUserServer && botUserRegex.MatchString(jid.User) && jid.Device == 0) || jid.Server == BotServer
+	if jid.Server == BotServer {
+		return true
+	} else if jid.Server != DefaultUserServer || jid.Device != 0 {
+		return false
+	}
+	return botUserRegex.MatchString(jid.User) || isRegisteredBotPhone(jid)
 }
 
 // NewADJID creates a new AD JID.
@@ -196,6 +214,15 @@ func ParseJID(jid string) (JID, error) {
 		}
 		parsedJID.Device = uint16(device)
 	}
+	if parsedJID.Server == InteropServer {
+		// If the prefix isn't a valid integrator ID, the user part is kept as-is
+		if integrator, user, ok := strings.Cut(parsedJID.User, "-"); ok {
+			if integratorID, err := strconv.ParseUint(integrator, 10, 16); err == nil && integratorID > 0 {
+				parsedJID.Integrator = uint16(integratorID)
+				parsedJID.User = user
+			}
+		}
+	}
 	return parsedJID, nil
 }
 
@@ -207,6 +234,15 @@ func NewJID(user, server string) JID {
 	}
 }
 
+// NewInteropJID creates a new JID for a user of a third-party messenger, identified by the integrator ID.
+func NewInteropJID(user string, integrator uint16) JID {
+	return JID{
+		User:       user,
+		Integrator: integrator,
+		Server:     InteropServer,
+	}
+}
+
 func (jid JID) ADString() string {
 	return fmt.Sprintf("%s.%d:%d@%s", jid.User, jid.RawAgent, jid.Device, jid.Server)
 }
@@ -214,7 +250,13 @@ func (jid JID) ADString() string {
 // String converts the JID to a string representation.
 // The output string can be parsed with ParseJID.
 func (jid JID) String() string {
-	if jid.RawAgent > 0 {
+	if jid.Integrator > 0 && jid.Server == InteropServer {
+		user := fmt.Sprintf("%d-%s", jid.Integrator, jid.User)
+		if jid.Device > 0 {
+			return fmt.Sprintf("%s:%d@%s", user, jid.Device, jid.Server)
+		}
+		return fmt.Sprintf("%s@%s", user, jid.Server)
+	} else if jid.RawAgent > 0 {
 		return fmt.Sprintf("%s.%d:%d@%s", jid.User, jid.RawAgent, jid.Device, jid.Server)
 	} else if jid.Device > 0 {
 		return fmt.Sprintf("%s:%d@%s", jid.User, jid.Device, jid.Server)
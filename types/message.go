@@ -9,6 +9,10 @@ package types
 import (
 	"fmt"
 	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"go.mau.fi/whatsmeow/proto/waCommon"
 )
 
 type AddressingMode string
@@ -23,6 +27,14 @@ type BroadcastRecipient struct {
 	PN  JID
 }
 
+// BroadcastList contains info about a broadcast list that was synced from app state.
+type BroadcastList struct {
+	JID        JID
+	Name       string
+	Recipients []BroadcastRecipient
+	LabelIDs   []string
+}
+
 // MessageSource contains basic sender and chat information about a message.
 type MessageSource struct {
 	Chat     JID  // The chat where the message was sent.
@@ -120,3 +132,18 @@ func (ms *MessageSource) SourceString() string {
 		return ms.Chat.String()
 	}
 }
+
+// NewMessageKey builds a message key for referring to another message, e.g. in replies, reactions or albums.
+//
+// The participant is only included for messages sent by other users in chats that aren't one-to-one chats.
+func NewMessageKey(chat, sender JID, id MessageID, fromMe bool) *waCommon.MessageKey {
+	key := &waCommon.MessageKey{
+		FromMe:    proto.Bool(fromMe),
+		ID:        proto.String(id),
+		RemoteJID: proto.String(chat.String()),
+	}
+	if !fromMe && chat.Server != DefaultUserServer && chat.Server != HiddenUserServer && chat.Server != MessengerServer && chat.Server != InteropServer {
+		key.Participant = proto.String(sender.ToNonAD().String())
+	}
+	return key
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package types
+
+import (
+	"time"
+)
+
+// PollInfo contains the metadata of a poll that is stored for tallying votes.
+type PollInfo struct {
+	Chat            JID
+	ID              MessageID
+	Creator         JID
+	Name            string
+	Options         []string
+	SelectableCount int
+	CreatedAt       time.Time
+}
+
+// PollVote is the latest vote of a single user in a poll.
+type PollVote struct {
+	Voter JID
+	// SHA-256 hashes of the selected option names. Empty if the user removed their vote.
+	SelectedHashes [][]byte
+	Timestamp      time.Time
+}
+
+// PollOptionResult contains the voters of a single poll option.
+type PollOptionResult struct {
+	Name   string
+	Hash   []byte
+	Voters []JID
+}
+
+// PollResults contains the current results of a poll.
+type PollResults struct {
+	Poll    PollInfo
+	Options []PollOptionResult
+	// The number of users who have currently selected at least one option.
+	TotalVoters int
+	// The timestamp of the latest vote.
+	UpdatedAt time.Time
+}
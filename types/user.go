@@ -29,6 +29,7 @@ type UserInfo struct {
 
 type BotListInfo struct {
 	BotJID    JID
+	PhoneJID  JID // The phone number JID of the bot. Only set if the server included a pn attribute in the bot list.
 	PersonaID string
 }
 
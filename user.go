@@ -250,6 +250,14 @@ func (cli *Client) GetUserInfo(ctx context.Context, jids []types.JID) (map[types
 	return respData, nil
 }
 
+// GetBotListV2 fetches the list of available bots.
+//
+// The list is also used to update the bot registry (see types.RegisterBots) and stored in Store.Bots.
+// This is done automatically after connecting, see DisableBotListRefresh.
+//
+// The phone number of a bot is only known if the server includes a pn attribute in the bot list entry,
+// which isn't confirmed to happen. Bots without one are still recognized by their bot JID, but they don't get
+// a phone number mapping, so GetBotPhoneJID keeps falling back to the static types.BotJIDMap for them.
 func (cli *Client) GetBotListV2(ctx context.Context) ([]types.BotListInfo, error) {
 	resp, err := cli.sendIQ(ctx, infoQuery{
 		To:        types.ServerJID,
@@ -276,10 +284,13 @@ func (cli *Client) GetBotListV2(ctx context.Context) ([]types.BotListInfo, error
 				list = append(list, types.BotListInfo{
 					PersonaID: ag.String("persona_id"),
 					BotJID:    ag.JID("jid"),
+					// Not every entry has a phone number, bots without one won't be mapped in the registry
+					PhoneJID: ag.OptionalJIDOrEmpty("pn"),
 				})
 			}
 		}
 	}
+	cli.updateBotRegistry(ctx, list)
 
 	return list, nil
 }
@@ -462,6 +473,9 @@ func (cli *Client) GetUserDevices(ctx context.Context, jids []types.JID) ([]type
 		} else if jid.IsBot() {
 			// Bot JIDs do not have devices, the usync query is empty
 			devices = append(devices, jid)
+		} else if jid.IsInterop() {
+			// Interop users are behind the third-party messenger's gateway, which handles the fanout to their devices
+			devices = append(devices, jid)
 		} else {
 			jidsToSync = append(jidsToSync, jid)
 		}